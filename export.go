@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alexdresko/terraform-provider-seq/internal/provider"
+)
+
+// runExport implements `terraform-provider-seq export`, which writes
+// Terraform configuration and import blocks for the entities on a Seq
+// server. It connects using the same SEQ_* environment variables as the
+// provider.
+func runExport(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-seq export [flags]\n\n"+
+			"Writes .tf files with resource and import blocks for the entities on the Seq\n"+
+			"server configured by SEQ_SERVER_URL, SEQ_API_KEY and the other SEQ_* settings.\n\n"+
+			"Flags:\n")
+		fs.PrintDefaults()
+	}
+	dir := fs.String("out", "seq-export", "directory to write .tf files to; existing files are never overwritten")
+	types := fs.String("types", "", "comma-separated resource types to export, e.g. seq_api_key,permalink (default: "+strings.Join(provider.ExportTypes(), ",")+")")
+	excludeSecrets := fs.Bool("exclude-secrets", true, "leave sensitive values such as the provider API key out of the generated files")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments: %s\n", strings.Join(fs.Args(), " "))
+		fs.Usage()
+		return 2
+	}
+
+	ctx := context.Background()
+	client, diags := provider.NewClientFromConfig(ctx, provider.SeqProviderModel{})
+	for _, d := range diags {
+		fmt.Fprintf(stderr, "%s: %s: %s\n", d.Severity(), d.Summary(), d.Detail())
+	}
+	if diags.HasError() {
+		return 1
+	}
+
+	opts := provider.ExportOptions{Dir: *dir, ExcludeSecrets: *excludeSecrets}
+	if *types != "" {
+		opts.Types = strings.Split(*types, ",")
+	}
+	written, err := provider.Export(ctx, client, opts)
+	for _, p := range written {
+		fmt.Fprintf(stdout, "Wrote %s\n", p)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	return 0
+}
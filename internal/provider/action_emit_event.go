@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	frameworkvalidator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+var _ action.Action = (*EmitEventAction)(nil)
+var _ action.ActionWithConfigure = (*EmitEventAction)(nil)
+
+// EmitEventAction posts a single structured event to Seq, e.g. a deployment
+// marker after an apply.
+//
+// Ref: https://datalust.co/docs/posting-raw-events
+type EmitEventAction struct {
+	client *seqapi.Client
+}
+
+type EmitEventModel struct {
+	MessageTemplate types.String  `tfsdk:"message_template"`
+	Level           types.String  `tfsdk:"level"`
+	Timestamp       types.String  `tfsdk:"timestamp"`
+	Properties      types.Dynamic `tfsdk:"properties"`
+	APIKey          types.String  `tfsdk:"api_key"`
+}
+
+func NewEmitEventAction() action.Action {
+	return &EmitEventAction{}
+}
+
+func (a *EmitEventAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_emit_event"
+}
+
+func (a *EmitEventAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Posts a structured event to Seq, e.g. a deployment marker.",
+		Attributes: map[string]schema.Attribute{
+			"message_template": schema.StringAttribute{
+				Description: "Message template for the event, e.g. \"Deployment of {Application} {Version} completed\". Placeholders are filled from properties.",
+				Required:    true,
+				Validators: []frameworkvalidator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"level": schema.StringAttribute{
+				Description: "Event level (Verbose, Debug, Information, Warning, Error, Fatal). Defaults to Information.",
+				Optional:    true,
+				Validators: []frameworkvalidator.String{
+					stringvalidator.OneOf("Verbose", "Debug", "Information", "Warning", "Error", "Fatal"),
+				},
+			},
+			"timestamp": schema.StringAttribute{
+				Description: "Event timestamp in RFC 3339 format. Defaults to the time the action runs.",
+				Optional:    true,
+			},
+			"properties": schema.DynamicAttribute{
+				Description: "Properties to attach to the event, as an object of property names to values.",
+				Optional:    true,
+			},
+			"api_key": schema.StringAttribute{
+				Description: "Ingestion API key to send the event with. Defaults to the provider's api_key. Action configuration is not stored in state.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (a *EmitEventAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*seqapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			"Expected *seqapi.Client, got a different type.",
+		)
+		return
+	}
+	a.client = client
+}
+
+func (a *EmitEventAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	if a.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing configured Seq client")
+		return
+	}
+
+	var config EmitEventModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	event, diags := clefEvent(config, time.Now())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := a.client.IngestCLEF(ctx, []map[string]any{event}, stringValue(config.APIKey)); err != nil {
+		resp.Diagnostics.AddError("Failed to send event to Seq", err.Error())
+		return
+	}
+
+	if resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{Message: "Sent event to Seq: " + config.MessageTemplate.ValueString()})
+	}
+}
+
+// clefEvent builds the CLEF representation of the configured event.
+func clefEvent(config EmitEventModel, now time.Time) (map[string]any, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	timestamp := now.UTC()
+	if ts := stringValue(config.Timestamp); ts != "" {
+		parsed, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			diags.AddAttributeError(path.Root("timestamp"), "Invalid timestamp", "timestamp must be in RFC 3339 format: "+err.Error())
+			return nil, diags
+		}
+		timestamp = parsed
+	}
+
+	event := map[string]any{
+		"@t":  timestamp.Format(time.RFC3339Nano),
+		"@mt": config.MessageTemplate.ValueString(),
+	}
+	if level := stringValue(config.Level); level != "" {
+		event["@l"] = level
+	}
+
+	props, err := appliedPropertiesToParts(config.Properties)
+	if err != nil {
+		diags.AddAttributeError(path.Root("properties"), "Invalid properties", err.Error())
+		return nil, diags
+	}
+	for _, prop := range props {
+		event[clefPropertyName(prop.Name)] = prop.Value
+	}
+
+	return event, diags
+}
+
+// clefPropertyName escapes user property names that would collide with
+// CLEF's reserved @-prefixed fields.
+func clefPropertyName(name string) string {
+	if strings.HasPrefix(name, "@") {
+		return "@" + name
+	}
+	return name
+}
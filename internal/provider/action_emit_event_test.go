@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+func invokeEmitEvent(t *testing.T, c *seqapi.Client, config EmitEventModel) diag.Diagnostics {
+	t.Helper()
+	ctx := context.Background()
+
+	a := NewEmitEventAction().(*EmitEventAction)
+	a.Configure(ctx, action.ConfigureRequest{ProviderData: c}, &action.ConfigureResponse{})
+
+	var schemaResp action.SchemaResponse
+	a.Schema(ctx, action.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	// Build the config by round-tripping the model through a state value.
+	cfgState := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	if diags := cfgState.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics building config: %v", diags)
+	}
+
+	resp := action.InvokeResponse{}
+	a.Invoke(ctx, action.InvokeRequest{Config: tfsdk.Config{Schema: s, Raw: cfgState.Raw}}, &resp)
+	return resp.Diagnostics
+}
+
+func TestEmitEventActionPostsCLEF(t *testing.T) {
+	var gotKey, gotContentType string
+	var gotEvent map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ingest/clef" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		gotKey = r.Header.Get("X-Seq-ApiKey")
+		gotContentType = r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &gotEvent); err != nil {
+			t.Errorf("body is not a single CLEF event: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"MinimumLevelAccepted":null}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client(), APIKey: "provider-key"})
+	diags := invokeEmitEvent(t, c, EmitEventModel{
+		MessageTemplate: types.StringValue("Deployed {Application} {Version}"),
+		Level:           types.StringValue("Information"),
+		Timestamp:       types.StringValue("2024-05-01T12:00:00Z"),
+		Properties: types.DynamicValue(types.ObjectValueMust(
+			map[string]attr.Type{"Application": types.StringType, "Version": types.StringType, "@Build": types.StringType},
+			map[string]attr.Value{
+				"Application": types.StringValue("shop"),
+				"Version":     types.StringValue("1.2.3"),
+				"@Build":      types.StringValue("42"),
+			},
+		)),
+		APIKey: types.StringValue("ingest-key"),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if gotKey != "ingest-key" {
+		t.Fatalf("expected the action api_key to override the provider key, got %q", gotKey)
+	}
+	if gotContentType != "application/vnd.serilog.clef" {
+		t.Fatalf("unexpected content type %q", gotContentType)
+	}
+	want := map[string]any{
+		"@t":          "2024-05-01T12:00:00Z",
+		"@mt":         "Deployed {Application} {Version}",
+		"@l":          "Information",
+		"Application": "shop",
+		"Version":     "1.2.3",
+		"@@Build":     "42",
+	}
+	for k, v := range want {
+		if gotEvent[k] != v {
+			t.Fatalf("expected %s=%v, got %v (event %v)", k, v, gotEvent[k], gotEvent)
+		}
+	}
+}
+
+func TestEmitEventActionReportsIngestionErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"Error":"The event is missing a timestamp"}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})
+	diags := invokeEmitEvent(t, c, EmitEventModel{
+		MessageTemplate: types.StringValue("Deployed"),
+		Properties:      types.DynamicNull(),
+	})
+	if !diags.HasError() {
+		t.Fatalf("expected an error diagnostic")
+	}
+	if !strings.Contains(diags.Errors()[0].Detail(), "missing a timestamp") {
+		t.Fatalf("expected Seq's error in the diagnostic, got %v", diags)
+	}
+
+	diags = invokeEmitEvent(t, c, EmitEventModel{
+		MessageTemplate: types.StringValue("Deployed"),
+		Timestamp:       types.StringValue("yesterday"),
+		Properties:      types.DynamicNull(),
+	})
+	if !diags.HasError() || diags.Errors()[0].Summary() != "Invalid timestamp" {
+		t.Fatalf("expected an invalid timestamp diagnostic, got %v", diags)
+	}
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+// appliedPropertiesToParts converts a dynamic properties value (an object or
+// map) into Seq event properties, preserving JSON value types.
+// Properties are sorted by name so request bodies are deterministic.
+func appliedPropertiesToParts(v types.Dynamic) ([]seqapi.EventProperty, error) {
+	if v.IsNull() || v.IsUnknown() || v.IsUnderlyingValueNull() {
+		return nil, nil
+	}
+
+	var elems map[string]attr.Value
+	switch under := v.UnderlyingValue().(type) {
+	case types.Object:
+		elems = under.Attributes()
+	case types.Map:
+		elems = under.Elements()
+	default:
+		return nil, fmt.Errorf("value must be an object or map, got %s", under.Type(context.Background()))
+	}
+
+	names := make([]string, 0, len(elems))
+	for name := range elems {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	props := make([]seqapi.EventProperty, 0, len(names))
+	for _, name := range names {
+		value, err := attrValueToJSON(elems[name])
+		if err != nil {
+			return nil, fmt.Errorf("property %q: %w", name, err)
+		}
+		props = append(props, seqapi.EventProperty{Name: name, Value: value})
+	}
+	return props, nil
+}
+
+// appliedPropertiesFromParts converts Seq event properties into a dynamic
+// object value, mapping JSON strings, numbers, booleans, arrays and objects
+// to the corresponding Terraform types.
+func appliedPropertiesFromParts(props []seqapi.EventProperty) types.Dynamic {
+	if len(props) == 0 {
+		return types.DynamicNull()
+	}
+
+	attrTypes := make(map[string]attr.Type, len(props))
+	attrs := make(map[string]attr.Value, len(props))
+	for _, prop := range props {
+		value := jsonToAttrValue(prop.Value)
+		attrTypes[prop.Name] = value.Type(context.Background())
+		attrs[prop.Name] = value
+	}
+	return types.DynamicValue(types.ObjectValueMust(attrTypes, attrs))
+}
+
+// appliedPropertiesEqual reports whether the dynamic value holds the same
+// properties and JSON values as props, ignoring the Terraform type shape
+// (e.g. a map versus an object with the same contents).
+func appliedPropertiesEqual(v types.Dynamic, props []seqapi.EventProperty) bool {
+	if v.IsUnknown() {
+		return false
+	}
+	current, err := appliedPropertiesToParts(v)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(normalizeProperties(current), normalizeProperties(props))
+}
+
+// normalizeProperties round-trips properties through JSON so values decoded
+// from Seq and values built from configuration compare equal.
+func normalizeProperties(props []seqapi.EventProperty) map[string]any {
+	out := make(map[string]any, len(props))
+	for _, prop := range props {
+		b, err := json.Marshal(prop.Value)
+		if err != nil {
+			out[prop.Name] = prop.Value
+			continue
+		}
+		var v any
+		_ = json.Unmarshal(b, &v)
+		out[prop.Name] = v
+	}
+	return out
+}
+
+func attrValueToJSON(v attr.Value) (any, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+	if v.IsUnknown() {
+		return nil, fmt.Errorf("value is unknown")
+	}
+
+	switch val := v.(type) {
+	case types.Dynamic:
+		return attrValueToJSON(val.UnderlyingValue())
+	case types.String:
+		return val.ValueString(), nil
+	case types.Bool:
+		return val.ValueBool(), nil
+	case types.Number:
+		return json.Number(val.ValueBigFloat().Text('g', -1)), nil
+	case types.Int64:
+		return val.ValueInt64(), nil
+	case types.Float64:
+		return val.ValueFloat64(), nil
+	case types.List:
+		return attrValuesToJSON(val.Elements())
+	case types.Set:
+		return attrValuesToJSON(val.Elements())
+	case types.Tuple:
+		return attrValuesToJSON(val.Elements())
+	case types.Object:
+		return attrMapToJSON(val.Attributes())
+	case types.Map:
+		return attrMapToJSON(val.Elements())
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", v)
+	}
+}
+
+func attrValuesToJSON(elems []attr.Value) (any, error) {
+	out := make([]any, 0, len(elems))
+	for _, elem := range elems {
+		v, err := attrValueToJSON(elem)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func attrMapToJSON(elems map[string]attr.Value) (any, error) {
+	out := make(map[string]any, len(elems))
+	for name, elem := range elems {
+		v, err := attrValueToJSON(elem)
+		if err != nil {
+			return nil, err
+		}
+		out[name] = v
+	}
+	return out, nil
+}
+
+func jsonToAttrValue(v any) attr.Value {
+	switch val := v.(type) {
+	case nil:
+		return types.DynamicNull()
+	case string:
+		return types.StringValue(val)
+	case bool:
+		return types.BoolValue(val)
+	case float64:
+		return types.NumberValue(big.NewFloat(val))
+	case json.Number:
+		f, _, err := big.ParseFloat(val.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return types.StringValue(val.String())
+		}
+		return types.NumberValue(f)
+	case []any:
+		elemTypes := make([]attr.Type, 0, len(val))
+		elems := make([]attr.Value, 0, len(val))
+		for _, e := range val {
+			ev := jsonToAttrValue(e)
+			elemTypes = append(elemTypes, ev.Type(context.Background()))
+			elems = append(elems, ev)
+		}
+		return types.TupleValueMust(elemTypes, elems)
+	case map[string]any:
+		attrTypes := make(map[string]attr.Type, len(val))
+		attrs := make(map[string]attr.Value, len(val))
+		for name, e := range val {
+			ev := jsonToAttrValue(e)
+			attrTypes[name] = ev.Type(context.Background())
+			attrs[name] = ev
+		}
+		return types.ObjectValueMust(attrTypes, attrs)
+	default:
+		return types.StringValue(fmt.Sprintf("%v", val))
+	}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/internal/seqfake"
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	cassette := filepath.Join(t.TempDir(), "seq.jsonl")
+	fake := seqfake.New(t, seqfake.WithVersion("2020.5.4778"), seqfake.WithAPIKey("admin-secret-key"))
+
+	t.Setenv(recordCassetteEnv, cassette)
+	c, diags := NewClientFromConfig(ctx, SeqProviderModel{
+		ServerURL: types.StringValue(fake.URL),
+		APIKey:    types.StringValue("admin-secret-key"),
+	})
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("expected only the recording warning, got %v", diags)
+	}
+
+	var created seqapi.APIKey
+	if err := c.DoJSON(ctx, http.MethodPost, "/api/apikeys", map[string]any{"Title": "k", "Permissions": []string{"Read"}}, &created); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DoJSON(ctx, http.MethodGet, "/api/apikeys/missing", nil, nil); !seqapi.IsNotFound(err) {
+		t.Fatalf("expected 404, got %v", err)
+	}
+
+	recorded, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"admin-secret-key", created.Token} {
+		if strings.Contains(string(recorded), secret) {
+			t.Fatalf("cassette contains secret %q:\n%s", secret, recorded)
+		}
+	}
+
+	// Replay against an unreachable server_url: everything comes from the
+	// cassette, including the legacy server version.
+	fake.Close()
+	t.Setenv(recordCassetteEnv, "")
+	t.Setenv(replayCassetteEnv, cassette)
+	c, diags = NewClientFromConfig(ctx, SeqProviderModel{ServerURL: types.StringValue("http://seq.invalid/")})
+	if diags.HasError() || diags.WarningsCount() != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got := c.APIKeyPermissionsField(); got != "Permissions" {
+		t.Fatalf("expected the recorded legacy version, got permissions field %q", got)
+	}
+
+	var replayed seqapi.APIKey
+	if err := c.DoJSON(ctx, http.MethodPost, "/api/apikeys", map[string]any{"Title": "k"}, &replayed); err != nil {
+		t.Fatal(err)
+	}
+	if replayed.ID != created.ID || len(replayed.Permissions) != 1 || replayed.Token != "***" {
+		t.Fatalf("replayed = %+v, want %s with a redacted token", replayed, created.ID)
+	}
+	if err := c.DoJSON(ctx, http.MethodGet, "/api/apikeys/missing", nil, nil); !seqapi.IsNotFound(err) {
+		t.Fatalf("expected replayed 404, got %v", err)
+	}
+	err = c.DoJSON(ctx, http.MethodGet, "/api/permalinks", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "no response for GET /api/permalinks") {
+		t.Fatalf("expected a missing interaction error, got %v", err)
+	}
+}
+
+func TestCassetteRejectsRecordAndReplay(t *testing.T) {
+	t.Setenv(recordCassetteEnv, "a.jsonl")
+	t.Setenv(replayCassetteEnv, "b.jsonl")
+	_, diags := NewClientFromConfig(context.Background(), SeqProviderModel{ServerURL: types.StringValue("http://seq.invalid")})
+	if !diags.HasError() {
+		t.Fatal("expected an error when both cassette variables are set")
+	}
+}
@@ -1,13 +1,8 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"crypto/tls"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"os"
 	"strconv"
@@ -17,19 +12,20 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
 )
 
-// Client is a minimal HTTP client for talking to the Seq HTTP API.
-//
-// Authentication uses the X-Seq-ApiKey header (recommended by Seq).
-// Ref: https://datalust.co/docs/using-the-http-api
-type Client struct {
-	baseURL *url.URL
-	apiKey  string
-	http    *http.Client
-}
+// Environment variables that record Seq API traffic to, or replay it from, a
+// cassette file (see seqapi.Config).
+const (
+	recordCassetteEnv = "SEQ_RECORD_CASSETTE"
+	replayCassetteEnv = "SEQ_REPLAY_CASSETTE"
+)
 
-func NewClientFromConfig(ctx context.Context, cfg SeqProviderModel) (*Client, diag.Diagnostics) {
+// NewClientFromConfig builds a Seq API client from the provider configuration
+// and SEQ_* environment variables, then adapts it to the server's Seq version.
+func NewClientFromConfig(ctx context.Context, cfg SeqProviderModel) (*seqapi.Client, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	serverURL := firstNonEmpty(
@@ -76,97 +72,71 @@ func NewClientFromConfig(ctx context.Context, cfg SeqProviderModel) (*Client, di
 		}
 	}
 
-	httpClient := &http.Client{
-		Timeout: time.Duration(timeoutSeconds) * time.Second,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecureSkipVerify},
-		},
-	}
-
-	c := &Client{baseURL: parsed, apiKey: apiKey, http: httpClient}
-
-	// Best-effort connectivity check.
-	if err := c.Ping(ctx); err != nil {
-		tflog.Warn(ctx, "Seq provider configured, but /health check failed", map[string]any{"error": err.Error()})
-	}
-
-	return c, diags
-}
-
-func (c *Client) Ping(ctx context.Context) error {
-	var out map[string]any
-	return c.doJSON(ctx, http.MethodGet, "/health", nil, &out)
-}
-
-// doJSON performs an HTTP request with JSON body/response.
-func (c *Client) doJSON(ctx context.Context, method, path string, body any, out any) error {
-	fullURL, err := c.baseURL.Parse(strings.TrimPrefix(path, "/"))
-	if err != nil {
-		return err
-	}
-
-	var bodyReader io.Reader
-	if body != nil {
-		b, err := json.Marshal(body)
-		if err != nil {
-			return err
+	maxConcurrent := int64Value(cfg.MaxConcurrentRequests)
+	if env := os.Getenv("SEQ_MAX_CONCURRENT_REQUESTS"); env != "" {
+		if v, err := strconv.ParseInt(env, 10, 64); err == nil {
+			maxConcurrent = v
 		}
-		bodyReader = bytes.NewReader(b)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, fullURL.String(), bodyReader)
-	if err != nil {
-		return err
 	}
 
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	if c.apiKey != "" {
-		req.Header.Set("X-Seq-ApiKey", c.apiKey)
+	requestsPerSecond := float64Value(cfg.RequestsPerSecond)
+	if env := os.Getenv("SEQ_REQUESTS_PER_SECOND"); env != "" {
+		if v, err := strconv.ParseFloat(env, 64); err == nil {
+			requestsPerSecond = v
+		}
 	}
 
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return err
+	cacheReads := boolValue(cfg.CacheReads)
+	if env := os.Getenv("SEQ_CACHE_READS"); env != "" {
+		if v, err := strconv.ParseBool(env); err == nil {
+			cacheReads = v
+		}
 	}
-	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
+	recordCassette := os.Getenv(recordCassetteEnv)
+	replayCassette := os.Getenv(replayCassetteEnv)
+
+	c, err := seqapi.New(seqapi.Config{
+		ServerURL:             serverURL,
+		APIKey:                apiKey,
+		Timeout:               time.Duration(timeoutSeconds) * time.Second,
+		InsecureSkipVerify:    insecureSkipVerify,
+		MaxConcurrentRequests: maxConcurrent,
+		RequestsPerSecond:     requestsPerSecond,
+		CacheReads:            cacheReads,
+		RecordCassette:        recordCassette,
+		ReplayCassette:        replayCassette,
+	})
 	if err != nil {
-		return err
+		// The server URL was validated above, so this is the cassette.
+		diags.AddError("Invalid Seq cassette", err.Error())
+		return nil, diags
 	}
-
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		msg := strings.TrimSpace(string(data))
-		if msg == "" {
-			msg = resp.Status
-		}
-		return &HTTPError{StatusCode: resp.StatusCode, Message: msg}
+	switch {
+	case recordCassette != "":
+		diags.AddWarning(
+			"Recording Seq API traffic",
+			fmt.Sprintf("%s is set, so requests and responses are appended to %s. Secrets are redacted, but review the file before sharing it.", recordCassetteEnv, recordCassette),
+		)
+	case replayCassette != "":
+		tflog.Info(ctx, "Replaying Seq API responses from a cassette", map[string]any{"cassette": replayCassette})
 	}
 
-	if out == nil {
-		return nil
-	}
-	if len(bytes.TrimSpace(data)) == 0 {
-		return nil
+	// Best-effort connectivity check.
+	if err := c.Ping(ctx); err != nil {
+		tflog.Warn(ctx, "Seq provider configured, but /health check failed", map[string]any{"error": err.Error()})
 	}
 
-	if err := json.Unmarshal(data, out); err != nil {
-		return fmt.Errorf("decode JSON response: %w", err)
+	if err := c.DetectCapabilities(ctx); err != nil {
+		diags.AddWarning(
+			"Unable to detect Seq server version",
+			fmt.Sprintf("The provider will assume a current Seq version. If requests fail, check that the server is reachable and up to date. Error: %s", err),
+		)
+	} else {
+		tflog.Info(ctx, "Detected Seq server version", map[string]any{"version": c.ServerVersion()})
 	}
 
-	return nil
-}
-
-// HTTPError wraps non-2xx responses.
-type HTTPError struct {
-	StatusCode int
-	Message    string
-}
-
-func (e *HTTPError) Error() string {
-	return fmt.Sprintf("seq api returned %d: %s", e.StatusCode, e.Message)
+	return c, diags
 }
 
 func firstNonEmpty(vs ...string) string {
@@ -192,6 +162,13 @@ func boolValue(v types.Bool) bool {
 	return v.ValueBool()
 }
 
+func float64Value(v types.Float64) float64 {
+	if v.IsNull() || v.IsUnknown() {
+		return 0
+	}
+	return v.ValueFloat64()
+}
+
 func int64Value(v types.Int64) int64 {
 	if v.IsNull() || v.IsUnknown() {
 		return 0
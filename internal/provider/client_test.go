@@ -1,14 +1,72 @@
 package provider
 
 import (
-	"net/url"
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func mustURL(t *testing.T, s string) *url.URL {
-	u, err := url.Parse(s)
-	if err != nil {
-		t.Fatal(err)
+func newVersionServer(t *testing.T, rootStatus int, rootBody string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/health":
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		case "/api":
+			w.WriteHeader(rootStatus)
+			_, _ = w.Write([]byte(rootBody))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNewClientFromConfigDetectsCapabilities(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		field  string
+		warned bool
+	}{
+		{"current", `{"Product":"Seq","Version":"2024.3.13045"}`, "AssignedPermissions", false},
+		{"legacy", `{"Product":"Seq","Version":"2020.5.4766"}`, "Permissions", false},
+		{"pre-calendar", `{"Product":"Seq","Version":"5.1.3364"}`, "Permissions", false},
+		{"unknown", `{"Product":"Seq","Version":"dev"}`, "AssignedPermissions", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newVersionServer(t, http.StatusOK, tc.body)
+			c, diags := NewClientFromConfig(context.Background(), SeqProviderModel{ServerURL: types.StringValue(srv.URL)})
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got := c.APIKeyPermissionsField(); got != tc.field {
+				t.Fatalf("expected permissions field %q, got %q", tc.field, got)
+			}
+			if warned := diags.WarningsCount() > 0; warned != tc.warned {
+				t.Fatalf("expected warning=%v, got diagnostics %v", tc.warned, diags)
+			}
+		})
+	}
+}
+
+func TestNewClientFromConfigWarnsWhenRootUnavailable(t *testing.T) {
+	srv := newVersionServer(t, http.StatusInternalServerError, `{"Error":"boom"}`)
+	c, diags := NewClientFromConfig(context.Background(), SeqProviderModel{ServerURL: types.StringValue(srv.URL)})
+	if diags.HasError() {
+		t.Fatalf("unexpected error diagnostics: %v", diags)
+	}
+	if diags.WarningsCount() != 1 {
+		t.Fatalf("expected a version detection warning, got %v", diags)
+	}
+	if got := c.APIKeyPermissionsField(); got != "AssignedPermissions" {
+		t.Fatalf("expected fallback to AssignedPermissions, got %q", got)
 	}
-	return u
 }
@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+var _ datasource.DataSource = (*AlertStateDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*AlertStateDataSource)(nil)
+var _ datasource.DataSourceWithConfigValidators = (*AlertStateDataSource)(nil)
+
+// Alert states reported by seq_alert_state.
+const (
+	alertStateOK         = "ok"
+	alertStateFiring     = "firing"
+	alertStateSuppressed = "suppressed"
+	alertStateFailing    = "failing"
+)
+
+// AlertStateDataSource reads the runtime state of alerts via /api/alertstate.
+//
+// Ref: https://datalust.co/docs/server-http-api#api-alertstate
+type AlertStateDataSource struct {
+	client *seqapi.Client
+}
+
+type AlertStateModel struct {
+	AlertID   types.String `tfsdk:"alert_id"`
+	Title     types.String `tfsdk:"title"`
+	Alerts    types.List   `tfsdk:"alerts"`
+	AnyFiring types.Bool   `tfsdk:"any_firing"`
+}
+
+// alertStateResponse is an alert state entity returned by /api/alertstate.
+type alertStateResponse struct {
+	ID                   string                         `json:"Id"`
+	AlertID              string                         `json:"AlertId"`
+	Title                string                         `json:"Title"`
+	OwnerID              *string                        `json:"OwnerId"`
+	LastCheckTimeUtc     *string                        `json:"LastCheckTimeUtc"`
+	LastTriggeredTimeUtc *string                        `json:"LastTriggeredTimeUtc"`
+	SuppressedUntilUtc   *string                        `json:"SuppressedUntilUtc"`
+	IsFailing            bool                           `json:"IsFailing"`
+	NotificationFailures []alertNotificationFailurePart `json:"NotificationFailures"`
+}
+
+// alertNotificationFailurePart describes a notification that could not be
+// delivered when the alert triggered.
+type alertNotificationFailurePart struct {
+	TimestampUtc  string `json:"TimestampUtc"`
+	AppInstanceID string `json:"NotificationAppInstanceId"`
+	Message       string `json:"Message"`
+}
+
+var alertNotificationFailureAttrTypes = map[string]attr.Type{
+	"timestamp":       types.StringType,
+	"app_instance_id": types.StringType,
+	"message":         types.StringType,
+}
+
+var alertStateAttrTypes = map[string]attr.Type{
+	"id":                    types.StringType,
+	"title":                 types.StringType,
+	"owner_id":              types.StringType,
+	"state":                 types.StringType,
+	"last_check":            types.StringType,
+	"last_triggered":        types.StringType,
+	"suppressed_until":      types.StringType,
+	"notification_failures": types.ListType{ElemType: types.ObjectType{AttrTypes: alertNotificationFailureAttrTypes}},
+}
+
+func NewAlertStateDataSource() datasource.DataSource {
+	return &AlertStateDataSource{}
+}
+
+func (d *AlertStateDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alert_state"
+}
+
+func (d *AlertStateDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the current state of Seq alerts.",
+		Attributes: map[string]schema.Attribute{
+			"alert_id": schema.StringAttribute{
+				Description: "Only return the alert with this id.",
+				Optional:    true,
+			},
+			"title": schema.StringAttribute{
+				Description: "Only return alerts with exactly this title.",
+				Optional:    true,
+			},
+			"alerts": schema.ListNestedAttribute{
+				Description: "Matching alerts, ordered by id.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Alert id.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "Alert title.",
+							Computed:    true,
+						},
+						"owner_id": schema.StringAttribute{
+							Description: "Id of the user that owns the alert, if it is not shared.",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "Current state: \"failing\" if Seq could not check the alert, \"firing\" if it triggered on its most recent check, \"suppressed\" if notifications are suppressed after triggering, otherwise \"ok\".",
+							Computed:    true,
+						},
+						"last_check": schema.StringAttribute{
+							Description: "Time (RFC 3339) the alert was last checked, if ever.",
+							Computed:    true,
+						},
+						"last_triggered": schema.StringAttribute{
+							Description: "Time (RFC 3339) the alert last triggered, if ever.",
+							Computed:    true,
+						},
+						"suppressed_until": schema.StringAttribute{
+							Description: "Time (RFC 3339) until which notifications are suppressed, if any.",
+							Computed:    true,
+						},
+						"notification_failures": schema.ListNestedAttribute{
+							Description: "Recent notifications that could not be delivered.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"timestamp": schema.StringAttribute{
+										Description: "Time (RFC 3339) of the failed notification.",
+										Computed:    true,
+									},
+									"app_instance_id": schema.StringAttribute{
+										Description: "Id of the app instance that failed to send the notification.",
+										Computed:    true,
+									},
+									"message": schema.StringAttribute{
+										Description: "Failure message.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"any_firing": schema.BoolAttribute{
+				Description: "True if any matching alert triggered on its most recent check, including alerts whose state is \"failing\" because a later check failed.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *AlertStateDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(path.MatchRoot("alert_id"), path.MatchRoot("title")),
+	}
+}
+
+func (d *AlertStateDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*seqapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *seqapi.Client, got a different type.",
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *AlertStateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing configured Seq client")
+		return
+	}
+
+	var config AlertStateModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	alertID, title := stringValue(config.AlertID), stringValue(config.Title)
+	alertStatePath, err := d.client.ResourcePath(seqapi.ResourceAlertState)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read Seq alert state", err.Error())
+		return
+	}
+
+	var matched []alertStateResponse
+	for s, err := range seqapi.ListItems[alertStateResponse](ctx, d.client, alertStatePath) {
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read Seq alert state", err.Error())
+			return
+		}
+		if alertID != "" && s.alertID() != alertID {
+			continue
+		}
+		if title != "" && s.Title != title {
+			continue
+		}
+		matched = append(matched, s)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].alertID() < matched[j].alertID() })
+
+	if alertID != "" && len(matched) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("alert_id"), "Seq alert not found", "No alert state was found for alert "+alertID+".")
+		return
+	}
+
+	now := time.Now()
+	anyFiring := false
+	elems := make([]attr.Value, 0, len(matched))
+	for _, s := range matched {
+		state := s.state(now)
+		if s.triggered() {
+			anyFiring = true
+		}
+		elems = append(elems, alertStateValue(s, state))
+	}
+
+	state := config
+	state.Alerts = types.ListValueMust(types.ObjectType{AttrTypes: alertStateAttrTypes}, elems)
+	state.AnyFiring = types.BoolValue(anyFiring)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// alertID returns the id of the alert the state belongs to. Older Seq
+// versions only return the state entity's own id.
+func (s alertStateResponse) alertID() string {
+	return firstNonEmpty(s.AlertID, s.ID)
+}
+
+// state summarizes the alert state; failing takes precedence over firing,
+// which takes precedence over suppressed.
+func (s alertStateResponse) state(now time.Time) string {
+	if s.IsFailing {
+		return alertStateFailing
+	}
+	if s.triggered() {
+		return alertStateFiring
+	}
+	if s.SuppressedUntilUtc != nil {
+		if until, err := time.Parse(time.RFC3339Nano, *s.SuppressedUntilUtc); err == nil && until.After(now) {
+			return alertStateSuppressed
+		}
+	}
+	return alertStateOK
+}
+
+// triggered reports whether the alert triggered on its most recent check,
+// whether or not it is also failing.
+func (s alertStateResponse) triggered() bool {
+	return s.LastTriggeredTimeUtc != nil && s.LastCheckTimeUtc != nil && !timestampBefore(*s.LastTriggeredTimeUtc, *s.LastCheckTimeUtc)
+}
+
+// timestampBefore reports whether RFC 3339 timestamp a is before b, falling
+// back to string comparison if either does not parse.
+func timestampBefore(a, b string) bool {
+	ta, errA := time.Parse(time.RFC3339Nano, a)
+	tb, errB := time.Parse(time.RFC3339Nano, b)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return ta.Before(tb)
+}
+
+func alertStateValue(s alertStateResponse, state string) attr.Value {
+	failures := make([]attr.Value, 0, len(s.NotificationFailures))
+	for _, f := range s.NotificationFailures {
+		failures = append(failures, types.ObjectValueMust(alertNotificationFailureAttrTypes, map[string]attr.Value{
+			"timestamp":       types.StringValue(f.TimestampUtc),
+			"app_instance_id": optionalString(&f.AppInstanceID),
+			"message":         types.StringValue(f.Message),
+		}))
+	}
+
+	return types.ObjectValueMust(alertStateAttrTypes, map[string]attr.Value{
+		"id":                    types.StringValue(s.alertID()),
+		"title":                 types.StringValue(s.Title),
+		"owner_id":              optionalString(s.OwnerID),
+		"state":                 types.StringValue(state),
+		"last_check":            optionalString(s.LastCheckTimeUtc),
+		"last_triggered":        optionalString(s.LastTriggeredTimeUtc),
+		"suppressed_until":      optionalString(s.SuppressedUntilUtc),
+		"notification_failures": types.ListValueMust(types.ObjectType{AttrTypes: alertNotificationFailureAttrTypes}, failures),
+	})
+}
+
+// optionalString returns a null string for nil or empty values.
+func optionalString(s *string) types.String {
+	if s == nil || *s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(*s)
+}
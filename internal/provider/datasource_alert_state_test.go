@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+func TestAlertStateDataSourceFiltersAndStates(t *testing.T) {
+	suppressedUntil := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/alertstate" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"Id": "alertstate-2", "AlertId": "alert-2", "Title": "Checkout errors",
+			 "LastCheckTimeUtc": "2024-05-01T12:05:00Z", "LastTriggeredTimeUtc": "2024-05-01T12:05:00Z",
+			 "NotificationFailures": [{"TimestampUtc": "2024-05-01T12:05:01Z", "NotificationAppInstanceId": "appinstance-1", "Message": "SMTP timeout"}]},
+			{"Id": "alertstate-1", "AlertId": "alert-1", "Title": "Disk space",
+			 "LastCheckTimeUtc": "2024-05-01T12:05:00Z", "LastTriggeredTimeUtc": "2024-04-30T08:00:00Z"},
+			{"Id": "alertstate-3", "AlertId": "alert-3", "Title": "Checkout errors",
+			 "LastCheckTimeUtc": "2024-05-01T12:05:00Z", "LastTriggeredTimeUtc": "2024-05-01T11:00:00Z", "SuppressedUntilUtc": "` + suppressedUntil + `"},
+			{"Id": "alertstate-4", "AlertId": "alert-4", "Title": "Latency", "IsFailing": true},
+			{"Id": "alertstate-5", "AlertId": "alert-5", "Title": "Queue depth", "IsFailing": true,
+			 "LastCheckTimeUtc": "2024-05-01T12:05:00Z", "LastTriggeredTimeUtc": "2024-05-01T12:05:00Z"}
+		]`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})
+	read := func(config AlertStateModel) AlertStateModel {
+		t.Helper()
+		config.Alerts = types.ListNull(types.ObjectType{AttrTypes: alertStateAttrTypes})
+		state, diags := readDataSource(t, NewAlertStateDataSource(), c, &config)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		var got AlertStateModel
+		if diags := state.Get(context.Background(), &got); diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		return got
+	}
+
+	all := read(AlertStateModel{})
+	alerts := all.Alerts.Elements()
+	if len(alerts) != 5 || !all.AnyFiring.ValueBool() {
+		t.Fatalf("expected 5 alerts with one firing, got %d, any_firing=%v", len(alerts), all.AnyFiring)
+	}
+	wantStates := []string{alertStateOK, alertStateFiring, alertStateSuppressed, alertStateFailing, alertStateFailing}
+	for i, want := range wantStates {
+		attrs := alerts[i].(types.Object).Attributes()
+		if attrs["state"] != types.StringValue(want) {
+			t.Fatalf("alert %v: expected state %q, got %v", attrs["id"], want, attrs["state"])
+		}
+	}
+	failures := alerts[1].(types.Object).Attributes()["notification_failures"].(types.List).Elements()
+	if len(failures) != 1 || failures[0].(types.Object).Attributes()["message"] != types.StringValue("SMTP timeout") {
+		t.Fatalf("unexpected notification failures %v", failures)
+	}
+
+	byTitle := read(AlertStateModel{Title: types.StringValue("Checkout errors")})
+	if len(byTitle.Alerts.Elements()) != 2 || !byTitle.AnyFiring.ValueBool() {
+		t.Fatalf("expected 2 alerts titled Checkout errors, got %v", byTitle.Alerts)
+	}
+
+	byID := read(AlertStateModel{AlertID: types.StringValue("alert-1")})
+	if len(byID.Alerts.Elements()) != 1 || byID.AnyFiring.ValueBool() {
+		t.Fatalf("expected only alert-1, not firing, got %v", byID.Alerts)
+	}
+
+	// An alert that triggered on its latest check still counts as firing
+	// when it is also failing.
+	failingAndTriggered := read(AlertStateModel{AlertID: types.StringValue("alert-5")})
+	if !failingAndTriggered.AnyFiring.ValueBool() {
+		t.Fatalf("expected a failing alert that triggered to set any_firing, got %v", failingAndTriggered.Alerts)
+	}
+
+	failingOnly := read(AlertStateModel{AlertID: types.StringValue("alert-4")})
+	if failingOnly.AnyFiring.ValueBool() {
+		t.Fatalf("expected a failing alert that never triggered not to set any_firing")
+	}
+}
@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+var _ datasource.DataSource = (*APIKeyMetricsDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*APIKeyMetricsDataSource)(nil)
+
+// APIKeyMetricsDataSource reads ingestion metrics for one or all API keys via
+// /api/apikeys/{id}/metrics.
+//
+// Ref: https://datalust.co/docs/server-http-api#api-apikeys
+type APIKeyMetricsDataSource struct {
+	client *seqapi.Client
+}
+
+type APIKeyMetricsModel struct {
+	APIKeyID types.String `tfsdk:"api_key_id"`
+	Keys     types.List   `tfsdk:"keys"`
+}
+
+// apiKeyMetricsResponse is Seq's ApiKeyMetricsPart: event counts per minute
+// over the recent past, oldest first. A nil series was missing from the
+// document.
+type apiKeyMetricsResponse struct {
+	ArrivedEventsPerMinute    []int64 `json:"ArrivedEventsPerMinute"`
+	InfluencedEventsPerMinute []int64 `json:"InfluencedEventsPerMinute"`
+}
+
+var metricWindowsAttrTypes = map[string]attr.Type{
+	"last_minute": types.Int64Type,
+	"last_hour":   types.Int64Type,
+	"per_minute":  types.ListType{ElemType: types.Int64Type},
+}
+
+var apiKeyMetricsAttrTypes = map[string]attr.Type{
+	"id":                types.StringType,
+	"title":             types.StringType,
+	"arrived_events":    types.ObjectType{AttrTypes: metricWindowsAttrTypes},
+	"influenced_events": types.ObjectType{AttrTypes: metricWindowsAttrTypes},
+}
+
+func NewAPIKeyMetricsDataSource() datasource.DataSource {
+	return &APIKeyMetricsDataSource{}
+}
+
+func (d *APIKeyMetricsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_key_metrics"
+}
+
+func metricWindowsSchema(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: description + " Null if Seq did not report it.",
+		Computed:    true,
+		Attributes: map[string]schema.Attribute{
+			"last_minute": schema.Int64Attribute{
+				Description: "Count for the most recent minute Seq reports.",
+				Computed:    true,
+			},
+			"last_hour": schema.Int64Attribute{
+				Description: "Total over the most recent 60 minutes Seq reports.",
+				Computed:    true,
+			},
+			"per_minute": schema.ListAttribute{
+				Description: "Counts per minute as reported by Seq, oldest first.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *APIKeyMetricsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads recent ingestion metrics for one or all Seq API keys. Seq reports only per-minute event counts over a recent window (about the last hour), so longer-term idleness, ingested bytes and rejected events cannot be read from it.",
+		Attributes: map[string]schema.Attribute{
+			"api_key_id": schema.StringAttribute{
+				Description: "Id of the API key to read metrics for. If omitted, metrics for all API keys are returned.",
+				Optional:    true,
+			},
+			"keys": schema.ListNestedAttribute{
+				Description: "Metrics per API key, ordered by id.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Seq API key id.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "API key title.",
+							Computed:    true,
+						},
+						"arrived_events":    metricWindowsSchema("Number of events that arrived using the key."),
+						"influenced_events": metricWindowsSchema("Number of events the key's input settings changed or filtered out (minimum level, filter, applied properties)."),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *APIKeyMetricsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*seqapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *seqapi.Client, got a different type.",
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *APIKeyMetricsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing configured Seq client")
+		return
+	}
+
+	var config APIKeyMetricsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var keys []seqapi.APIKey
+	if id := stringValue(config.APIKeyID); id != "" {
+		key, err := d.client.GetAPIKey(ctx, id)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read Seq API key", err.Error())
+			return
+		}
+		keys = append(keys, key)
+	} else {
+		for key, err := range d.client.ListAPIKeys(ctx) {
+			if err != nil {
+				resp.Diagnostics.AddError("Failed to list Seq API keys", err.Error())
+				return
+			}
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+
+	apiKeysPath, err := d.client.ResourcePath(seqapi.ResourceAPIKeys)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read Seq API key metrics", err.Error())
+		return
+	}
+
+	elems := make([]attr.Value, 0, len(keys))
+	for _, key := range keys {
+		var metrics apiKeyMetricsResponse
+		metricsPath := apiKeysPath + "/" + url.PathEscape(key.ID) + "/metrics"
+		if err := d.client.DoJSON(ctx, http.MethodGet, metricsPath, nil, &metrics); err != nil {
+			resp.Diagnostics.AddError("Failed to read Seq API key metrics", "API key "+key.ID+": "+err.Error())
+			return
+		}
+		if metrics.ArrivedEventsPerMinute == nil && metrics.InfluencedEventsPerMinute == nil {
+			resp.Diagnostics.AddWarning(
+				"Unrecognized Seq API key metrics",
+				"API key "+key.ID+": the metrics document has neither ArrivedEventsPerMinute nor InfluencedEventsPerMinute, "+
+					"so this Seq version may report metrics in a different shape. The key's metrics are left null.",
+			)
+		}
+		elems = append(elems, apiKeyMetricsValue(key, metrics))
+	}
+
+	state := APIKeyMetricsModel{
+		APIKeyID: config.APIKeyID,
+		Keys:     types.ListValueMust(types.ObjectType{AttrTypes: apiKeyMetricsAttrTypes}, elems),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func apiKeyMetricsValue(key seqapi.APIKey, metrics apiKeyMetricsResponse) attr.Value {
+	return types.ObjectValueMust(apiKeyMetricsAttrTypes, map[string]attr.Value{
+		"id":                types.StringValue(key.ID),
+		"title":             types.StringValue(key.Title),
+		"arrived_events":    metricWindowsValue(metrics.ArrivedEventsPerMinute),
+		"influenced_events": metricWindowsValue(metrics.InfluencedEventsPerMinute),
+	})
+}
+
+// metricWindowsValue derives the windows from a per-minute series, which is
+// null if Seq did not report it.
+func metricWindowsValue(perMinute []int64) attr.Value {
+	if perMinute == nil {
+		return types.ObjectNull(metricWindowsAttrTypes)
+	}
+	var lastMinute, lastHour int64
+	if n := len(perMinute); n > 0 {
+		lastMinute = perMinute[n-1]
+	}
+	for _, count := range perMinute[max(0, len(perMinute)-60):] {
+		lastHour += count
+	}
+	counts := make([]attr.Value, 0, len(perMinute))
+	for _, count := range perMinute {
+		counts = append(counts, types.Int64Value(count))
+	}
+	return types.ObjectValueMust(metricWindowsAttrTypes, map[string]attr.Value{
+		"last_minute": types.Int64Value(lastMinute),
+		"last_hour":   types.Int64Value(lastHour),
+		"per_minute":  types.ListValueMust(types.Int64Type, counts),
+	})
+}
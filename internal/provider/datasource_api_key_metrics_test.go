@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+// apiKeyMetricsBusy is an ApiKeyMetricsPart document in the shape Seq
+// returns: one count per minute for the last hour, oldest first.
+const apiKeyMetricsBusy = `{"ArrivedEventsPerMinute":[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,12,40,38,0,0,7,95,120,64,31],"InfluencedEventsPerMinute":[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,2,5,0,3]}`
+
+const apiKeyMetricsIdle = `{"ArrivedEventsPerMinute":[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0],"InfluencedEventsPerMinute":[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]}`
+
+func TestAPIKeyMetricsDataSourceReadsAllKeys(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/apikeys":
+			_, _ = w.Write([]byte(`[{"Id":"apikey-2","Title":"idle"},{"Id":"apikey-1","Title":"busy"}]`))
+		case "/api/apikeys/apikey-1/metrics":
+			_, _ = w.Write([]byte(apiKeyMetricsBusy))
+		case "/api/apikeys/apikey-2/metrics":
+			_, _ = w.Write([]byte(apiKeyMetricsIdle))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})
+	state, diags := readDataSource(t, NewAPIKeyMetricsDataSource(), c, &APIKeyMetricsModel{
+		APIKeyID: types.StringNull(),
+		Keys:     types.ListNull(types.ObjectType{AttrTypes: apiKeyMetricsAttrTypes}),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var got APIKeyMetricsModel
+	if diags := state.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	keys := got.Keys.Elements()
+	if len(keys) != 2 {
+		t.Fatalf("expected 2 keys, got %d", len(keys))
+	}
+
+	busy := keys[0].(types.Object).Attributes()
+	if busy["id"] != types.StringValue("apikey-1") {
+		t.Fatalf("expected keys ordered by id, got %v first", busy["id"])
+	}
+	arrived := busy["arrived_events"].(types.Object).Attributes()
+	if arrived["last_minute"] != types.Int64Value(31) {
+		t.Fatalf("unexpected arrived_events.last_minute %v", arrived["last_minute"])
+	}
+	if arrived["last_hour"] != types.Int64Value(407) {
+		t.Fatalf("unexpected arrived_events.last_hour %v", arrived["last_hour"])
+	}
+	if n := len(arrived["per_minute"].(types.List).Elements()); n != 60 {
+		t.Fatalf("expected 60 per-minute counts, got %d", n)
+	}
+	influenced := busy["influenced_events"].(types.Object).Attributes()
+	if influenced["last_hour"] != types.Int64Value(10) {
+		t.Fatalf("unexpected influenced_events.last_hour %v", influenced["last_hour"])
+	}
+
+	idle := keys[1].(types.Object).Attributes()
+	if idle["arrived_events"].(types.Object).Attributes()["last_hour"] != types.Int64Value(0) {
+		t.Fatalf("expected idle key to report zero events")
+	}
+}
+
+func TestAPIKeyMetricsDataSourceWarnsOnUnrecognizedMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/apikeys/apikey-1":
+			_, _ = w.Write([]byte(`{"Id":"apikey-1","Title":"ingest"}`))
+		case "/api/apikeys/apikey-1/metrics":
+			_, _ = w.Write([]byte(`{"ArrivedEvents":{"LastHour":300}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})
+	state, diags := readDataSource(t, NewAPIKeyMetricsDataSource(), c, &APIKeyMetricsModel{
+		APIKeyID: types.StringValue("apikey-1"),
+		Keys:     types.ListNull(types.ObjectType{AttrTypes: apiKeyMetricsAttrTypes}),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != "Unrecognized Seq API key metrics" {
+		t.Fatalf("expected an unrecognized metrics warning, got %v", diags)
+	}
+
+	var got APIKeyMetricsModel
+	if diags := state.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	key := got.Keys.Elements()[0].(types.Object).Attributes()
+	if !key["arrived_events"].IsNull() || !key["influenced_events"].IsNull() {
+		t.Fatalf("expected null metrics, got %v", key)
+	}
+}
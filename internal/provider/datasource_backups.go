@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+var _ datasource.DataSource = (*BackupsDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*BackupsDataSource)(nil)
+
+// BackupsDataSource lists the backups Seq has retained via /api/backups.
+//
+// Ref: https://datalust.co/docs/server-http-api#api-backups
+type BackupsDataSource struct {
+	client *seqapi.Client
+}
+
+type BackupsModel struct {
+	Backups types.List `tfsdk:"backups"`
+}
+
+var backupAttrTypes = map[string]attr.Type{
+	"id":         types.StringType,
+	"filename":   types.StringType,
+	"created_at": types.StringType,
+	"size_bytes": types.Int64Type,
+}
+
+func NewBackupsDataSource() datasource.DataSource {
+	return &BackupsDataSource{}
+}
+
+func (d *BackupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backups"
+}
+
+func (d *BackupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the backups retained by the Seq server.",
+		Attributes: map[string]schema.Attribute{
+			"backups": schema.ListNestedAttribute{
+				Description: "Retained backups, newest first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Seq backup id.",
+							Computed:    true,
+						},
+						"filename": schema.StringAttribute{
+							Description: "Backup file name on the Seq server.",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "Time (RFC 3339) the backup was created.",
+							Computed:    true,
+						},
+						"size_bytes": schema.Int64Attribute{
+							Description: "Backup size in bytes.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *BackupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*seqapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *seqapi.Client, got a different type.",
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *BackupsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing configured Seq client")
+		return
+	}
+
+	var backups []seqapi.Backup
+	for b, err := range d.client.ListBackups(ctx) {
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to list Seq backups", err.Error())
+			return
+		}
+		backups = append(backups, b)
+	}
+	sort.SliceStable(backups, func(i, j int) bool { return timestampBefore(backups[j].CreatedAt, backups[i].CreatedAt) })
+
+	elems := make([]attr.Value, 0, len(backups))
+	for _, b := range backups {
+		elems = append(elems, types.ObjectValueMust(backupAttrTypes, map[string]attr.Value{
+			"id":         types.StringValue(b.ID),
+			"filename":   types.StringValue(b.Filename),
+			"created_at": types.StringValue(b.CreatedAt),
+			"size_bytes": types.Int64Value(b.SizeBytes),
+		}))
+	}
+
+	state := BackupsModel{
+		Backups: types.ListValueMust(types.ObjectType{AttrTypes: backupAttrTypes}, elems),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	frameworkvalidator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+var _ datasource.DataSource = (*EventsDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*EventsDataSource)(nil)
+
+const (
+	defaultMaxEvents = 100
+	maxMaxEvents     = 1000
+	eventsPageSize   = 100
+)
+
+// EventsDataSource searches recent events via /api/events.
+//
+// Ref: https://datalust.co/docs/server-http-api#api-events
+type EventsDataSource struct {
+	client *seqapi.Client
+}
+
+type EventsModel struct {
+	Filter    types.String `tfsdk:"filter"`
+	SignalIDs types.List   `tfsdk:"signal_ids"`
+	Range     types.String `tfsdk:"range"`
+	FromDate  types.String `tfsdk:"from_date"`
+	ToDate    types.String `tfsdk:"to_date"`
+	MaxEvents types.Int64  `tfsdk:"max_events"`
+	Events    types.List   `tfsdk:"events"`
+	Truncated types.Bool   `tfsdk:"truncated"`
+}
+
+// eventResponse is an event entity returned by /api/events.
+type eventResponse struct {
+	ID              string                 `json:"Id"`
+	Timestamp       string                 `json:"Timestamp"`
+	Level           string                 `json:"Level"`
+	RenderedMessage string                 `json:"RenderedMessage"`
+	Exception       string                 `json:"Exception"`
+	Properties      []seqapi.EventProperty `json:"Properties"`
+}
+
+var eventAttrTypes = map[string]attr.Type{
+	"id":         types.StringType,
+	"timestamp":  types.StringType,
+	"level":      types.StringType,
+	"message":    types.StringType,
+	"exception":  types.StringType,
+	"properties": types.MapType{ElemType: types.StringType},
+}
+
+func NewEventsDataSource() datasource.DataSource {
+	return &EventsDataSource{}
+}
+
+func (d *EventsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_events"
+}
+
+func (d *EventsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Searches recent Seq events.",
+		Attributes: map[string]schema.Attribute{
+			"filter": schema.StringAttribute{
+				Description: "Filter expression the events must match, e.g. \"@Level = 'Fatal' and Application = 'shop'\".",
+				Optional:    true,
+				Validators: []frameworkvalidator.String{
+					filterExpressionValidator{},
+				},
+			},
+			"signal_ids": schema.ListAttribute{
+				Description: "Ids of signals the events must match (all of them).",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"range": schema.StringAttribute{
+				Description: "Only return events from this far back, e.g. \"10m\", \"1h\" or \"7d\". Ignored when from_date is set.",
+				Optional:    true,
+			},
+			"from_date": schema.StringAttribute{
+				Description: "Only return events at or after this time (RFC 3339).",
+				Optional:    true,
+			},
+			"to_date": schema.StringAttribute{
+				Description: "Only return events before this time (RFC 3339).",
+				Optional:    true,
+			},
+			"max_events": schema.Int64Attribute{
+				Description: "Maximum number of events to retrieve, newest first. Defaults to 100; at most 1000.",
+				Optional:    true,
+				Validators: []frameworkvalidator.Int64{
+					int64validator.Between(1, maxMaxEvents),
+				},
+			},
+			"events": schema.ListNestedAttribute{
+				Description: "Matching events, newest first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Event id.",
+							Computed:    true,
+						},
+						"timestamp": schema.StringAttribute{
+							Description: "Event timestamp.",
+							Computed:    true,
+						},
+						"level": schema.StringAttribute{
+							Description: "Event level.",
+							Computed:    true,
+						},
+						"message": schema.StringAttribute{
+							Description: "Rendered event message.",
+							Computed:    true,
+						},
+						"exception": schema.StringAttribute{
+							Description: "Exception attached to the event, if any.",
+							Computed:    true,
+						},
+						"properties": schema.MapAttribute{
+							Description: "Event properties. String values are returned as-is; other values are JSON-encoded (use jsondecode to read them).",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+			"truncated": schema.BoolAttribute{
+				Description: "True if more events matched than max_events allowed.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *EventsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*seqapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *seqapi.Client, got a different type.",
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *EventsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing configured Seq client")
+		return
+	}
+
+	var config EventsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := url.Values{"render": {"true"}}
+	if filter := stringValue(config.Filter); filter != "" {
+		query.Set("filter", filter)
+	}
+	if !config.SignalIDs.IsNull() && !config.SignalIDs.IsUnknown() {
+		var signals []string
+		resp.Diagnostics.Append(config.SignalIDs.ElementsAs(ctx, &signals, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(signals) > 0 {
+			query.Set("signal", strings.Join(signals, ","))
+		}
+	}
+
+	tr, diags := resolveTimeRange(config.Range, config.FromDate, config.ToDate, time.Now(), 0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !tr.From.IsZero() {
+		query.Set("fromDateUtc", tr.From.Format(time.RFC3339Nano))
+	}
+	if !tr.To.IsZero() {
+		query.Set("toDateUtc", tr.To.Format(time.RFC3339Nano))
+	}
+
+	maxEvents := int(int64Value(config.MaxEvents))
+	if maxEvents == 0 {
+		maxEvents = defaultMaxEvents
+	}
+
+	eventsPath, err := d.client.ResourcePath(seqapi.ResourceEvents)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to search Seq events", err.Error())
+		return
+	}
+
+	// Fetch one extra event so we can tell whether the results were truncated.
+	var events []eventResponse
+	opts := seqapi.PageOptions{PageSize: eventsPageSize, Limit: maxEvents + 1}
+	for e, err := range seqapi.ListPages(ctx, d.client, eventsPath, query, opts, eventID) {
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to search Seq events", err.Error())
+			return
+		}
+		events = append(events, e)
+	}
+
+	truncated := len(events) > maxEvents
+	if truncated {
+		events = events[:maxEvents]
+	}
+
+	elems := make([]attr.Value, 0, len(events))
+	for _, e := range events {
+		elems = append(elems, eventValue(e))
+	}
+
+	state := config
+	state.Events = types.ListValueMust(types.ObjectType{AttrTypes: eventAttrTypes}, elems)
+	state.Truncated = types.BoolValue(truncated)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// eventID is the paging cursor for /api/events.
+func eventID(e eventResponse) string {
+	return e.ID
+}
+
+func eventValue(e eventResponse) attr.Value {
+	props := make(map[string]attr.Value, len(e.Properties))
+	for _, p := range e.Properties {
+		props[p.Name] = types.StringValue(propertyValueString(p.Value))
+	}
+
+	exception := types.StringNull()
+	if e.Exception != "" {
+		exception = types.StringValue(e.Exception)
+	}
+
+	return types.ObjectValueMust(eventAttrTypes, map[string]attr.Value{
+		"id":         types.StringValue(e.ID),
+		"timestamp":  types.StringValue(e.Timestamp),
+		"level":      types.StringValue(e.Level),
+		"message":    types.StringValue(e.RenderedMessage),
+		"exception":  exception,
+		"properties": types.MapValueMust(types.StringType, props),
+	})
+}
+
+// propertyValueString returns strings unchanged and JSON-encodes other values.
+func propertyValueString(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
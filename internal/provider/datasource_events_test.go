@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+func TestEventsDataSourcePagesAndCaps(t *testing.T) {
+	// 250 matching events, newest first, ids event-0 .. event-249.
+	const total = 250
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/events" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		q := r.URL.Query()
+		requests = append(requests, q.Encode())
+		if q.Get("filter") != "@Level = 'Fatal'" || q.Get("signal") != "signal-1,signal-2" || q.Get("fromDateUtc") == "" {
+			t.Errorf("unexpected query %v", q)
+		}
+
+		start := 0
+		if after := q.Get("afterId"); after != "" {
+			fmt.Sscanf(after, "event-%d", &start)
+			start++
+		}
+		count, _ := strconv.Atoi(q.Get("count"))
+
+		var page []map[string]any
+		for i := start; i < total && len(page) < count; i++ {
+			page = append(page, map[string]any{
+				"Id":              fmt.Sprintf("event-%d", i),
+				"Timestamp":       "2024-05-01T12:00:00Z",
+				"Level":           "Fatal",
+				"RenderedMessage": "Crashed",
+				"Properties": []map[string]any{
+					{"Name": "Application", "Value": "shop"},
+					{"Name": "Attempt", "Value": 3},
+				},
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})
+	state, diags := readDataSource(t, NewEventsDataSource(), c, &EventsModel{
+		Filter:    types.StringValue("@Level = 'Fatal'"),
+		SignalIDs: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("signal-1"), types.StringValue("signal-2")}),
+		Range:     types.StringValue("10m"),
+		MaxEvents: types.Int64Value(150),
+		Events:    types.ListNull(types.ObjectType{AttrTypes: eventAttrTypes}),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var got EventsModel
+	if diags := state.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 paged requests, got %d: %v", len(requests), requests)
+	}
+	events := got.Events.Elements()
+	if len(events) != 150 {
+		t.Fatalf("expected 150 events, got %d", len(events))
+	}
+	if !got.Truncated.ValueBool() {
+		t.Fatalf("expected truncated to be true")
+	}
+
+	last := events[149].(types.Object).Attributes()
+	if last["id"] != types.StringValue("event-149") {
+		t.Fatalf("unexpected last event id %v", last["id"])
+	}
+	props := last["properties"].(types.Map).Elements()
+	if props["Application"] != types.StringValue("shop") || props["Attempt"] != types.StringValue("3") {
+		t.Fatalf("unexpected properties %v", props)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
 )
 
 var _ datasource.DataSource = (*HealthDataSource)(nil)
@@ -16,7 +18,7 @@ var _ datasource.DataSourceWithConfigure = (*HealthDataSource)(nil)
 //
 // Ref: https://datalust.co/docs/server-http-api#health
 type HealthDataSource struct {
-	client *Client
+	client *seqapi.Client
 }
 
 type HealthModel struct {
@@ -47,11 +49,11 @@ func (d *HealthDataSource) Configure(_ context.Context, req datasource.Configure
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*Client)
+	client, ok := req.ProviderData.(*seqapi.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			"Expected *provider.Client, got a different type.",
+			"Expected *seqapi.Client, got a different type.",
 		)
 		return
 	}
@@ -65,7 +67,7 @@ func (d *HealthDataSource) Read(ctx context.Context, _ datasource.ReadRequest, r
 	}
 
 	var body map[string]any
-	if err := d.client.doJSON(ctx, http.MethodGet, "/health", nil, &body); err != nil {
+	if err := d.client.DoJSON(ctx, http.MethodGet, "/health", nil, &body); err != nil {
 		resp.Diagnostics.AddError("Failed to read Seq /health", err.Error())
 		return
 	}
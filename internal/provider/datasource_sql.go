@@ -0,0 +1,348 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	frameworkvalidator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+var _ datasource.DataSource = (*SQLDataSource)(nil)
+var _ datasource.DataSourceWithConfigure = (*SQLDataSource)(nil)
+
+const (
+	defaultSQLRange          = 24 * time.Hour
+	defaultSQLTimeoutSeconds = 30
+	maxSQLTimeoutSeconds     = 600
+	defaultSQLMaxRows        = 1000
+	maxSQLMaxRows            = 10000
+	sqlTimeColumn            = "time"
+)
+
+// SQLDataSource runs a SQL-style query via /api/data.
+//
+// Ref: https://datalust.co/docs/server-http-api#api-data
+type SQLDataSource struct {
+	client *seqapi.Client
+}
+
+type SQLModel struct {
+	Query          types.String `tfsdk:"query"`
+	SignalIDs      types.List   `tfsdk:"signal_ids"`
+	Range          types.String `tfsdk:"range"`
+	FromDate       types.String `tfsdk:"from_date"`
+	ToDate         types.String `tfsdk:"to_date"`
+	TimeoutSeconds types.Int64  `tfsdk:"timeout_seconds"`
+	MaxRows        types.Int64  `tfsdk:"max_rows"`
+	Columns        types.List   `tfsdk:"columns"`
+	Rows           types.List   `tfsdk:"rows"`
+	Truncated      types.Bool   `tfsdk:"truncated"`
+}
+
+// queryResultResponse is the result document returned by /api/data. Grouped
+// by time queries return Slices instead of Rows. When the query fails, Error
+// (and usually Reasons) are set instead.
+type queryResultResponse struct {
+	Columns    []string        `json:"Columns"`
+	Rows       [][]any         `json:"Rows"`
+	Slices     []timeSlicePart `json:"Slices"`
+	Error      string          `json:"Error"`
+	Reasons    []string        `json:"Reasons"`
+	Suggestion string          `json:"Suggestion"`
+}
+
+type timeSlicePart struct {
+	Time string  `json:"Time"`
+	Rows [][]any `json:"Rows"`
+}
+
+func NewSQLDataSource() datasource.DataSource {
+	return &SQLDataSource{}
+}
+
+func (d *SQLDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sql"
+}
+
+func (d *SQLDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs a SQL query against the Seq event store.",
+		Attributes: map[string]schema.Attribute{
+			"query": schema.StringAttribute{
+				Description: "SQL query to run, e.g. \"select count(*) from stream group by Application\".",
+				Required:    true,
+				Validators: []frameworkvalidator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"signal_ids": schema.ListAttribute{
+				Description: "Ids of signals to restrict the query to (all of them).",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"range": schema.StringAttribute{
+				Description: "Query events from this far back, e.g. \"10m\", \"1h\" or \"7d\". Ignored when from_date is set. Defaults to \"1d\".",
+				Optional:    true,
+			},
+			"from_date": schema.StringAttribute{
+				Description: "Query events at or after this time (RFC 3339).",
+				Optional:    true,
+			},
+			"to_date": schema.StringAttribute{
+				Description: "Query events before this time (RFC 3339). Defaults to now.",
+				Optional:    true,
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				Description: "How long Seq may spend running the query. Defaults to 30; at most 600.",
+				Optional:    true,
+				Validators: []frameworkvalidator.Int64{
+					int64validator.Between(1, maxSQLTimeoutSeconds),
+				},
+			},
+			"max_rows": schema.Int64Attribute{
+				Description: "Maximum number of rows to return. Defaults to 1000; at most 10000. Seq is asked for one row more (with a `limit` clause, unless the query ends with one) to detect truncation.",
+				Optional:    true,
+				Validators: []frameworkvalidator.Int64{
+					int64validator.Between(1, maxSQLMaxRows),
+				},
+			},
+			"columns": schema.ListAttribute{
+				Description: "Result column names, in order. Queries grouped by time() get a leading \"time\" column.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"rows": schema.ListAttribute{
+				Description: "Result rows as maps keyed by column name. String values are returned as-is; other values are JSON-encoded (use jsondecode to read them).",
+				Computed:    true,
+				ElementType: types.MapType{ElemType: types.StringType},
+			},
+			"truncated": schema.BoolAttribute{
+				Description: "True if the query returned more rows than max_rows allowed.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *SQLDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*seqapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *seqapi.Client, got a different type.",
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *SQLDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing configured Seq client")
+		return
+	}
+
+	var config SQLModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxRows := int(int64Value(config.MaxRows))
+	if maxRows == 0 {
+		maxRows = defaultSQLMaxRows
+	}
+
+	sql := config.Query.ValueString()
+	query := url.Values{"q": {limitQuery(sql, maxRows+1)}}
+	if !config.SignalIDs.IsNull() && !config.SignalIDs.IsUnknown() {
+		var signals []string
+		resp.Diagnostics.Append(config.SignalIDs.ElementsAs(ctx, &signals, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(signals) > 0 {
+			query.Set("signal", strings.Join(signals, ","))
+		}
+	}
+
+	tr, diags := resolveTimeRange(config.Range, config.FromDate, config.ToDate, time.Now(), defaultSQLRange)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	query.Set("rangeStartUtc", tr.From.Format(time.RFC3339Nano))
+	if !tr.To.IsZero() {
+		query.Set("rangeEndUtc", tr.To.Format(time.RFC3339Nano))
+	}
+
+	timeout := time.Duration(int64Value(config.TimeoutSeconds)) * time.Second
+	if timeout == 0 {
+		timeout = defaultSQLTimeoutSeconds * time.Second
+	}
+	query.Set("timeoutMS", strconv.FormatInt(timeout.Milliseconds(), 10))
+
+	dataPath, err := d.client.ResourcePath(seqapi.ResourceData)
+	if err != nil {
+		resp.Diagnostics.AddError("Seq query failed", err.Error())
+		return
+	}
+
+	// Give Seq a little longer than the query timeout to report its own
+	// timeout error before the request is abandoned. The query may run longer
+	// than the provider's request timeout, so only this deadline applies.
+	reqCtx, cancel := context.WithTimeout(seqapi.WithoutTimeout(ctx), timeout+10*time.Second)
+	defer cancel()
+
+	var result queryResultResponse
+	if err := d.client.DoJSON(reqCtx, http.MethodGet, dataPath+"?"+query.Encode(), nil, &result); err != nil {
+		var httpErr *seqapi.HTTPError
+		if errors.As(err, &httpErr) && json.Unmarshal([]byte(httpErr.Body), &result) == nil && result.Error != "" {
+			resp.Diagnostics.AddAttributeError(path.Root("query"), "Seq query failed", queryErrorDetail(sql, result))
+			return
+		}
+		resp.Diagnostics.AddError("Failed to run Seq query", err.Error())
+		return
+	}
+	if result.Error != "" {
+		resp.Diagnostics.AddAttributeError(path.Root("query"), "Seq query failed", queryErrorDetail(sql, result))
+		return
+	}
+
+	columns, rows := queryResultTable(result)
+	truncated := len(rows) > maxRows
+	if truncated {
+		rows = rows[:maxRows]
+	}
+
+	columnElems := make([]attr.Value, 0, len(columns))
+	for _, c := range columns {
+		columnElems = append(columnElems, types.StringValue(c))
+	}
+	rowElems := make([]attr.Value, 0, len(rows))
+	for _, row := range rows {
+		rowElems = append(rowElems, queryRowValue(columns, row))
+	}
+
+	state := config
+	state.Columns = types.ListValueMust(types.StringType, columnElems)
+	state.Rows = types.ListValueMust(types.MapType{ElemType: types.StringType}, rowElems)
+	state.Truncated = types.BoolValue(truncated)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// queryLimitClause matches a limit clause at the end of a query.
+var queryLimitClause = regexp.MustCompile(`(?i)\blimit\s+\d+\s*;?\s*$`)
+
+// limitQuery asks Seq to return at most rows rows by appending a limit
+// clause, unless the query already ends with one. The clause goes on its own
+// line so positions in Seq's error messages still match the query as
+// written.
+func limitQuery(sql string, rows int) string {
+	if queryLimitClause.MatchString(sql) {
+		return sql
+	}
+	return strings.TrimRight(sql, " \t\r\n;") + "\nlimit " + strconv.Itoa(rows)
+}
+
+// queryResultTable flattens a query result into columns and rows. Time-sliced
+// results get a leading time column holding each slice's timestamp.
+func queryResultTable(result queryResultResponse) ([]string, [][]any) {
+	if len(result.Slices) == 0 {
+		return result.Columns, result.Rows
+	}
+
+	columns := append([]string{sqlTimeColumn}, result.Columns...)
+	var rows [][]any
+	for _, slice := range result.Slices {
+		for _, row := range slice.Rows {
+			rows = append(rows, append([]any{slice.Time}, row...))
+		}
+	}
+	return columns, rows
+}
+
+func queryRowValue(columns []string, row []any) attr.Value {
+	elems := make(map[string]attr.Value, len(columns))
+	for i, column := range columns {
+		if i >= len(row) || row[i] == nil {
+			elems[column] = types.StringNull()
+			continue
+		}
+		elems[column] = types.StringValue(propertyValueString(row[i]))
+	}
+	return types.MapValueMust(types.StringType, elems)
+}
+
+// queryErrorPosition matches the positions Seq's query engine reports, e.g.
+// "Syntax error (line 1, column 8)" or "Syntax error (1:8)".
+var queryErrorPosition = regexp.MustCompile(`(?i)line\s+(\d+),\s*column\s+(\d+)|\((\d+):(\d+)\)`)
+
+// queryErrorDetail describes a query engine error. When Seq reports a
+// position, the offending query line is included with a marker under the
+// reported column.
+func queryErrorDetail(sql string, result queryResultResponse) string {
+	var b strings.Builder
+	b.WriteString(result.Error)
+	for _, reason := range result.Reasons {
+		if reason != "" && reason != result.Error {
+			b.WriteString("\n" + reason)
+		}
+	}
+	if result.Suggestion != "" {
+		b.WriteString("\n\nSuggestion: " + result.Suggestion)
+	}
+
+	if line, column, ok := queryErrorLocation(result.Error); ok {
+		lines := strings.Split(sql, "\n")
+		if line <= len(lines) {
+			text := strings.TrimRight(lines[line-1], "\r")
+			if column > len(text)+1 {
+				column = len(text) + 1
+			}
+			fmt.Fprintf(&b, "\n\nAt line %d, column %d:\n    %s\n    %s^", line, column, text, strings.Repeat(" ", column-1))
+		}
+	}
+	return b.String()
+}
+
+// queryErrorLocation extracts a 1-based line and column from msg.
+func queryErrorLocation(msg string) (int, int, bool) {
+	m := queryErrorPosition.FindStringSubmatch(msg)
+	if m == nil {
+		return 0, 0, false
+	}
+	lineText, columnText := m[1], m[2]
+	if lineText == "" {
+		lineText, columnText = m[3], m[4]
+	}
+	line, err := strconv.Atoi(lineText)
+	if err != nil || line < 1 {
+		return 0, 0, false
+	}
+	column, err := strconv.Atoi(columnText)
+	if err != nil || column < 1 {
+		return 0, 0, false
+	}
+	return line, column, true
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+func TestSQLDataSourceRowsAndLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/data" || q.Get("q") != "select count(*) from stream group by Application\nlimit 3" || q.Get("rangeStartUtc") == "" || q.Get("timeoutMS") != "5000" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"Columns": []string{"Application", "count(*)"},
+			"Rows": [][]any{
+				{"shop", 12},
+				{"billing", 3},
+				{nil, 1},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})
+	state, diags := readDataSource(t, NewSQLDataSource(), c, &SQLModel{
+		Query:          types.StringValue("select count(*) from stream group by Application"),
+		TimeoutSeconds: types.Int64Value(5),
+		MaxRows:        types.Int64Value(2),
+		SignalIDs:      types.ListNull(types.StringType),
+		Columns:        types.ListNull(types.StringType),
+		Rows:           types.ListNull(types.MapType{ElemType: types.StringType}),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var got SQLModel
+	if diags := state.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(got.Columns.Elements()) != 2 || !got.Truncated.ValueBool() {
+		t.Fatalf("unexpected columns %v or truncated %v", got.Columns, got.Truncated)
+	}
+	rows := got.Rows.Elements()
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	first := rows[0].(types.Map).Elements()
+	if first["Application"] != types.StringValue("shop") || first["count(*)"] != types.StringValue("12") {
+		t.Fatalf("unexpected first row %v", first)
+	}
+}
+
+func TestLimitQuery(t *testing.T) {
+	for _, tc := range []struct{ sql, want string }{
+		{"select * from stream", "select * from stream\nlimit 11"},
+		{"select * from stream;\n", "select * from stream\nlimit 11"},
+		{"select * from stream limit 5", "select * from stream limit 5"},
+		{"select * from stream\nLIMIT 5;", "select * from stream\nLIMIT 5;"},
+	} {
+		if got := limitQuery(tc.sql, 11); got != tc.want {
+			t.Errorf("limitQuery(%q) = %q, want %q", tc.sql, got, tc.want)
+		}
+	}
+}
+
+func TestSQLDataSourceTimeSlices(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"Columns": []string{"count(*)"},
+			"Slices": []map[string]any{
+				{"Time": "2024-05-01T12:00:00Z", "Rows": [][]any{{4}}},
+				{"Time": "2024-05-01T13:00:00Z", "Rows": [][]any{{7}}},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})
+	state, diags := readDataSource(t, NewSQLDataSource(), c, &SQLModel{
+		Query:     types.StringValue("select count(*) from stream group by time(1h)"),
+		SignalIDs: types.ListNull(types.StringType),
+		Columns:   types.ListNull(types.StringType),
+		Rows:      types.ListNull(types.MapType{ElemType: types.StringType}),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var got SQLModel
+	if diags := state.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if got.Columns.Elements()[0] != types.StringValue("time") {
+		t.Fatalf("expected leading time column, got %v", got.Columns)
+	}
+	second := got.Rows.Elements()[1].(types.Map).Elements()
+	if second["time"] != types.StringValue("2024-05-01T13:00:00Z") || second["count(*)"] != types.StringValue("7") {
+		t.Fatalf("unexpected second row %v", second)
+	}
+}
+
+func TestSQLDataSourceQueryErrorShowsPosition(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"Error":   "Syntax error (line 2, column 6): unexpected `grup`.",
+			"Reasons": []string{"Syntax error (line 2, column 6): unexpected `grup`."},
+		})
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})
+	_, diags := readDataSource(t, NewSQLDataSource(), c, &SQLModel{
+		Query:     types.StringValue("select count(*) from stream\nwhere grup by Application"),
+		SignalIDs: types.ListNull(types.StringType),
+		Columns:   types.ListNull(types.StringType),
+		Rows:      types.ListNull(types.MapType{ElemType: types.StringType}),
+	})
+	if !diags.HasError() {
+		t.Fatalf("expected an error")
+	}
+	detail := diags.Errors()[0].Detail()
+	want := "At line 2, column 6:\n    where grup by Application\n         ^"
+	if !strings.Contains(detail, want) {
+		t.Fatalf("expected detail to contain %q, got %q", want, detail)
+	}
+}
+
+func TestQueryErrorLocation(t *testing.T) {
+	cases := map[string][2]int{
+		"Syntax error (line 3, column 14): unexpected `,`.": {3, 14},
+		"Syntax error (1:8): unexpected keyword `from`.":    {1, 8},
+	}
+	for msg, want := range cases {
+		line, column, ok := queryErrorLocation(msg)
+		if !ok || line != want[0] || column != want[1] {
+			t.Fatalf("queryErrorLocation(%q) = %d, %d, %v", msg, line, column, ok)
+		}
+	}
+	if _, _, ok := queryErrorLocation("The query timed out."); ok {
+		t.Fatalf("expected no location")
+	}
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+// readDataSource runs ds.Read with the given configuration model and returns
+// the resulting state.
+func readDataSource(t *testing.T, ds datasource.DataSource, client *seqapi.Client, config any) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp datasource.SchemaResponse
+	ds.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	// Build the config by round-tripping the model through a state value.
+	cfgState := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	if diags := cfgState.Set(ctx, config); diags.HasError() {
+		t.Fatalf("unexpected diagnostics building config: %v", diags)
+	}
+
+	if c, ok := ds.(datasource.DataSourceWithConfigure); ok {
+		var configureResp datasource.ConfigureResponse
+		c.Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &configureResp)
+		if configureResp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics configuring: %v", configureResp.Diagnostics)
+		}
+	}
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}}
+	ds.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: cfgState.Raw}}, &resp)
+	return resp.State, resp.Diagnostics
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+// errorDiagnostics reports err under summary. Validation failures for request
+// properties listed in fields are reported against the corresponding
+// attribute; field names are matched case-insensitively.
+func errorDiagnostics(summary string, err error, fields map[string]path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var httpErr *seqapi.HTTPError
+	if !errors.As(err, &httpErr) || len(httpErr.FieldErrors) == 0 || len(fields) == 0 {
+		diags.AddError(summary, err.Error())
+		return diags
+	}
+
+	unmapped := false
+	for _, field := range httpErr.FieldNames() {
+		attrPath, ok := lookupField(fields, field)
+		if !ok {
+			unmapped = true
+			continue
+		}
+		for _, msg := range httpErr.FieldErrors[field] {
+			diags.AddAttributeError(attrPath, summary, msg)
+		}
+	}
+	if unmapped || !diags.HasError() {
+		diags.AddError(summary, err.Error())
+	}
+	return diags
+}
+
+func lookupField(fields map[string]path.Path, field string) (path.Path, bool) {
+	for name, p := range fields {
+		if strings.EqualFold(name, field) {
+			return p, true
+		}
+	}
+	return path.Empty(), false
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+func TestErrorDiagnosticsMapsFieldErrors(t *testing.T) {
+	err := &seqapi.HTTPError{
+		StatusCode: http.StatusBadRequest,
+		Message:    "One or more validation errors occurred.",
+		FieldErrors: map[string][]string{
+			"Title":                      {"The Title field is required."},
+			"inputSettings.minimumLevel": {"Unknown level 'Loud'."},
+		},
+	}
+
+	diags := errorDiagnostics("Failed to create Seq API key", err, apiKeyFieldPaths)
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", diags)
+	}
+	byPath := map[string]string{}
+	for _, d := range diags {
+		withPath, ok := d.(interface{ Path() path.Path })
+		if !ok {
+			t.Fatalf("expected an attribute diagnostic, got %v", d)
+		}
+		byPath[withPath.Path().String()] = d.Detail()
+	}
+	if byPath["title"] != "The Title field is required." || byPath["minimum_level"] != "Unknown level 'Loud'." {
+		t.Fatalf("unexpected diagnostics %v", byPath)
+	}
+
+	// Unmapped fields fall back to a general error with the full message.
+	diags = errorDiagnostics("Failed to create Seq API key", err, map[string]path.Path{"Title": path.Root("title")})
+	if len(diags) != 2 || !strings.Contains(diags[1].Detail(), "inputSettings.minimumLevel: Unknown level 'Loud'.") {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+}
+
+func TestErrorDiagnosticsMapsNestedFilterFields(t *testing.T) {
+	for _, field := range []string{"InputSettings.Filter.Filter", "inputSettings.filter.filterNonStrict"} {
+		err := &seqapi.HTTPError{
+			StatusCode:  http.StatusBadRequest,
+			Message:     "One or more validation errors occurred.",
+			FieldErrors: map[string][]string{field: {"Syntax error (1:8)."}},
+		}
+
+		diags := errorDiagnostics("Failed to create Seq API key", err, apiKeyFieldPaths)
+		if len(diags) != 1 {
+			t.Fatalf("%s: expected 1 diagnostic, got %v", field, diags)
+		}
+		withPath, ok := diags[0].(interface{ Path() path.Path })
+		if !ok || !withPath.Path().Equal(path.Root("filter")) || diags[0].Detail() != "Syntax error (1:8)." {
+			t.Fatalf("%s: expected a filter attribute error, got %v", field, diags[0])
+		}
+	}
+}
@@ -0,0 +1,368 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+// ExportOptions configures Export.
+type ExportOptions struct {
+	// Dir is the directory the .tf files are written to. It is created if
+	// needed; existing files are not overwritten.
+	Dir string
+	// Types limits the export to these resource types, e.g. "seq_api_key" or
+	// "api_key". All types are exported if empty.
+	Types []string
+	// ExcludeSecrets leaves sensitive values out of the generated files. The
+	// provider's API key is then read from a sensitive variable.
+	ExcludeSecrets bool
+}
+
+// exportedEntity is one Seq entity to write as a resource block.
+type exportedEntity struct {
+	// Name is the preferred resource name, made unique and valid on export.
+	Name     string
+	ImportID string
+	// State is the resource model, as Read would store it.
+	State any
+}
+
+// resourceExporter reads the entities of one resource type for Export.
+type resourceExporter struct {
+	typeName string
+	resource func() resource.Resource
+	list     func(ctx context.Context, c *seqapi.Client) ([]exportedEntity, error)
+}
+
+// resourceExporters are the resource types Export supports, in output order.
+var resourceExporters = []resourceExporter{
+	{typeName: "seq_api_key", resource: NewAPIKeyResource, list: exportAPIKeys},
+	{typeName: "seq_permalink", resource: NewPermalinkResource, list: exportPermalinks},
+	{typeName: "seq_backup_settings", resource: NewBackupSettingsResource, list: exportBackupSettings},
+}
+
+// ExportTypes returns the resource types Export supports.
+func ExportTypes() []string {
+	names := make([]string, 0, len(resourceExporters))
+	for _, e := range resourceExporters {
+		names = append(names, e.typeName)
+	}
+	return names
+}
+
+// Export reads the entities on the Seq server and writes Terraform
+// configuration for them, with import blocks, into opts.Dir: one file per
+// resource type plus provider.tf. It returns the paths written.
+func Export(ctx context.Context, c *seqapi.Client, opts ExportOptions) ([]string, error) {
+	exporters, err := selectExporters(opts.Types)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	files := map[string]*hclwrite.File{"provider.tf": exportProviderFile(c, opts)}
+	order := []string{"provider.tf"}
+	for _, e := range exporters {
+		entities, err := e.list(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", e.typeName, err)
+		}
+		if len(entities) == 0 {
+			continue
+		}
+		f, err := exportResourceFile(ctx, e, entities, opts)
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", e.typeName, err)
+		}
+		name := e.typeName + ".tf"
+		files[name] = f
+		order = append(order, name)
+	}
+
+	var written []string
+	for _, name := range order {
+		p := filepath.Join(opts.Dir, name)
+		// O_EXCL so an export never clobbers existing configuration.
+		out, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return written, err
+		}
+		_, err = out.Write(files[name].Bytes())
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return written, err
+		}
+		written = append(written, p)
+	}
+	return written, nil
+}
+
+func selectExporters(types []string) ([]resourceExporter, error) {
+	if len(types) == 0 {
+		return resourceExporters, nil
+	}
+	want := map[string]bool{}
+	for _, t := range types {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if !strings.HasPrefix(t, "seq_") {
+			t = "seq_" + t
+		}
+		want[t] = true
+	}
+	var selected []resourceExporter
+	for _, e := range resourceExporters {
+		if want[e.typeName] {
+			selected = append(selected, e)
+			delete(want, e.typeName)
+		}
+	}
+	if len(want) > 0 {
+		unknown := make([]string, 0, len(want))
+		for t := range want {
+			unknown = append(unknown, t)
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unsupported resource types %s; supported types are %s", strings.Join(unknown, ", "), strings.Join(ExportTypes(), ", "))
+	}
+	return selected, nil
+}
+
+func exportProviderFile(c *seqapi.Client, opts ExportOptions) *hclwrite.File {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+
+	tf := body.AppendNewBlock("terraform", nil).Body()
+	providers := tf.AppendNewBlock("required_providers", nil).Body()
+	providers.SetAttributeValue("seq", cty.ObjectVal(map[string]cty.Value{
+		"source": cty.StringVal("alexdresko/seq"),
+	}))
+	body.AppendNewline()
+
+	p := body.AppendNewBlock("provider", []string{"seq"}).Body()
+	p.SetAttributeValue("server_url", cty.StringVal(c.ServerURL()))
+	switch {
+	case c.APIKey() == "":
+	case opts.ExcludeSecrets:
+		p.SetAttributeTraversal("api_key", hcl.Traversal{
+			hcl.TraverseRoot{Name: "var"},
+			hcl.TraverseAttr{Name: "seq_api_key"},
+		})
+		body.AppendNewline()
+		v := body.AppendNewBlock("variable", []string{"seq_api_key"}).Body()
+		v.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+		v.SetAttributeValue("sensitive", cty.True)
+	default:
+		p.SetAttributeValue("api_key", cty.StringVal(c.APIKey()))
+	}
+	return f
+}
+
+// exportResourceFile writes a resource and an import block per entity. Only
+// configurable attributes are written, in name order like Terraform's own
+// generated configuration.
+func exportResourceFile(ctx context.Context, e resourceExporter, entities []exportedEntity, opts ExportOptions) (*hclwrite.File, error) {
+	var schemaResp resource.SchemaResponse
+	e.resource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	names := make([]string, 0, len(s.Attributes))
+	for name, a := range s.Attributes {
+		if !a.IsRequired() && !a.IsOptional() {
+			continue
+		}
+		if opts.ExcludeSecrets && a.IsSensitive() {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	used := map[string]bool{}
+	for i, entity := range entities {
+		state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+		if diags := state.Set(ctx, entity.State); diags.HasError() {
+			return nil, diagnosticsError(diags)
+		}
+		var values map[string]tftypes.Value
+		if err := state.Raw.As(&values); err != nil {
+			return nil, err
+		}
+
+		name := uniqueResourceName(entity.Name, used)
+		if i > 0 {
+			body.AppendNewline()
+		}
+		r := body.AppendNewBlock("resource", []string{e.typeName, name}).Body()
+		for _, attrName := range names {
+			v := values[attrName]
+			if v.IsNull() {
+				continue
+			}
+			cv, err := ctyValue(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", name, attrName, err)
+			}
+			r.SetAttributeValue(attrName, cv)
+		}
+
+		body.AppendNewline()
+		imp := body.AppendNewBlock("import", nil).Body()
+		imp.SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: e.typeName},
+			hcl.TraverseAttr{Name: name},
+		})
+		imp.SetAttributeValue("id", cty.StringVal(entity.ImportID))
+	}
+	return f, nil
+}
+
+// diagnosticsError converts error diagnostics to an error.
+func diagnosticsError(diags diag.Diagnostics) error {
+	var errs []error
+	for _, d := range diags.Errors() {
+		errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return errors.Join(errs...)
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// uniqueResourceName turns s into a valid resource name that is not yet in
+// used, e.g. "Ingest (web)" into "ingest_web".
+func uniqueResourceName(s string, used map[string]bool) string {
+	name := strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(s), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "seq_" + name
+		name = strings.TrimSuffix(name, "_")
+	}
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// ctyValue converts a Terraform value to its cty equivalent for hclwrite.
+// Lists and sets are written as tuples and maps as objects, which Terraform
+// converts to the attribute's type.
+func ctyValue(v tftypes.Value) (cty.Value, error) {
+	if v.IsNull() {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+	if !v.IsKnown() {
+		return cty.NilVal, fmt.Errorf("unknown value")
+	}
+
+	typ := v.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return cty.StringVal(s), err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := v.As(&b)
+		return cty.BoolVal(b), err
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		err := v.As(&n)
+		return cty.NumberVal(n), err
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		if err := v.As(&elems); err != nil {
+			return cty.NilVal, err
+		}
+		if len(elems) == 0 {
+			return cty.EmptyTupleVal, nil
+		}
+		out := make([]cty.Value, 0, len(elems))
+		for _, elem := range elems {
+			cv, err := ctyValue(elem)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			out = append(out, cv)
+		}
+		return cty.TupleVal(out), nil
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var attrs map[string]tftypes.Value
+		if err := v.As(&attrs); err != nil {
+			return cty.NilVal, err
+		}
+		if len(attrs) == 0 {
+			return cty.EmptyObjectVal, nil
+		}
+		out := make(map[string]cty.Value, len(attrs))
+		for k, elem := range attrs {
+			cv, err := ctyValue(elem)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			out[k] = cv
+		}
+		return cty.ObjectVal(out), nil
+	}
+	return cty.NilVal, fmt.Errorf("unsupported type %s", typ)
+}
+
+func exportAPIKeys(ctx context.Context, c *seqapi.Client) ([]exportedEntity, error) {
+	var entities []exportedEntity
+	for key, err := range c.ListAPIKeys(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		state := APIKeyModel{Permissions: types.SetNull(types.StringType)}
+		applyAPIKeyResponse(&state, key)
+		entities = append(entities, exportedEntity{Name: key.Title, ImportID: key.ID, State: &state})
+	}
+	return entities, nil
+}
+
+func exportPermalinks(ctx context.Context, c *seqapi.Client) ([]exportedEntity, error) {
+	var entities []exportedEntity
+	for permalink, err := range c.ListPermalinks(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		var state PermalinkModel
+		applyPermalinkResponse(c, &state, permalink)
+		entities = append(entities, exportedEntity{Name: permalink.ID, ImportID: permalink.ID, State: &state})
+	}
+	return entities, nil
+}
+
+func exportBackupSettings(ctx context.Context, c *seqapi.Client) ([]exportedEntity, error) {
+	var state BackupSettingsModel
+	if diags := (&BackupSettingsResource{client: c}).read(ctx, &state); diags.HasError() {
+		return nil, diagnosticsError(diags)
+	}
+	return []exportedEntity{{Name: "this", ImportID: backupSettingsID, State: &state}}, nil
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+func newExportServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/apikeys":
+			_, _ = w.Write([]byte(`[
+				{"Id": "apikey-1", "Title": "Ingest (web)", "Token": "tok-123", "AssignedPermissions": ["Ingest"],
+				 "InputSettings": {"MinimumLevel": "Warning", "AppliedProperties": [{"Name": "App", "Value": "web"}, {"Name": "Shard", "Value": 3}]}},
+				{"Id": "apikey-2", "Title": "ci", "AssignedPermissions": ["Read"]},
+				{"Id": "apikey-3", "Title": "ci", "AssignedPermissions": []}
+			]`))
+		case "/api/permalinks":
+			_, _ = w.Write([]byte(`[]`))
+		case "/api/settings/BackupLocation":
+			_, _ = w.Write([]byte(`{"Name": "BackupLocation", "Value": "/backups"}`))
+		case "/api/settings/BackupUtcTimeOfDay":
+			_, _ = w.Write([]byte(`{"Name": "BackupUtcTimeOfDay", "Value": "02:30:00"}`))
+		case "/api/settings/BackupsToKeep":
+			_, _ = w.Write([]byte(`{"Name": "BackupsToKeep", "Value": 7}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func readExported(t *testing.T, dir, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("read %s: %v", name, err)
+	}
+	return string(b)
+}
+
+func TestExport(t *testing.T) {
+	srv := newExportServer(t)
+	defer srv.Close()
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client(), APIKey: "provider-key-1234"})
+	dir := t.TempDir()
+
+	written, err := Export(context.Background(), c, ExportOptions{Dir: dir, ExcludeSecrets: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// No permalinks, so no seq_permalink.tf.
+	var names []string
+	for _, p := range written {
+		names = append(names, filepath.Base(p))
+	}
+	if strings.Join(names, ",") != "provider.tf,seq_api_key.tf,seq_backup_settings.tf" {
+		t.Fatalf("unexpected files %v", names)
+	}
+
+	wantKeys := `resource "seq_api_key" "ingest_web" {
+  applied_properties = {
+    App   = "web"
+    Shard = 3
+  }
+  minimum_level = "Warning"
+  permissions   = ["Ingest"]
+  title         = "Ingest (web)"
+}
+
+import {
+  to = seq_api_key.ingest_web
+  id = "apikey-1"
+}
+
+resource "seq_api_key" "ci" {
+  permissions = ["Read"]
+  title       = "ci"
+}
+
+import {
+  to = seq_api_key.ci
+  id = "apikey-2"
+}
+
+resource "seq_api_key" "ci_2" {
+  permissions = []
+  title       = "ci"
+}
+
+import {
+  to = seq_api_key.ci_2
+  id = "apikey-3"
+}
+`
+	if got := readExported(t, dir, "seq_api_key.tf"); got != wantKeys {
+		t.Fatalf("unexpected seq_api_key.tf:\n%s", got)
+	}
+
+	if got := readExported(t, dir, "seq_backup_settings.tf"); !strings.Contains(got, `utc_time_of_day = "02:30"`) || !strings.Contains(got, `id = "backup-settings"`) {
+		t.Fatalf("unexpected seq_backup_settings.tf:\n%s", got)
+	}
+
+	provider := readExported(t, dir, "provider.tf")
+	if strings.Contains(provider, "provider-key-1234") || !strings.Contains(provider, "api_key    = var.seq_api_key") {
+		t.Fatalf("expected the API key to come from a variable:\n%s", provider)
+	}
+	if !strings.Contains(provider, `server_url = "`+srv.URL+`"`) {
+		t.Fatalf("unexpected provider.tf:\n%s", provider)
+	}
+
+	// A second export must not overwrite the first.
+	if _, err := Export(context.Background(), c, ExportOptions{Dir: dir}); !os.IsExist(err) {
+		t.Fatalf("expected an existing file error, got %v", err)
+	}
+}
+
+func TestExportWithSecretsAndTypes(t *testing.T) {
+	srv := newExportServer(t)
+	defer srv.Close()
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client(), APIKey: "provider-key-1234"})
+	dir := t.TempDir()
+
+	written, err := Export(context.Background(), c, ExportOptions{Dir: dir, Types: []string{"backup_settings"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(written) != 2 || filepath.Base(written[1]) != "seq_backup_settings.tf" {
+		t.Fatalf("unexpected files %v", written)
+	}
+	if provider := readExported(t, dir, "provider.tf"); !strings.Contains(provider, `api_key    = "provider-key-1234"`) || strings.Contains(provider, "variable") {
+		t.Fatalf("expected the API key inline:\n%s", provider)
+	}
+}
+
+func TestExportRejectsUnknownTypes(t *testing.T) {
+	_, err := Export(context.Background(), newTestClient(t, seqapi.Config{ServerURL: "http://seq.invalid"}), ExportOptions{Dir: t.TempDir(), Types: []string{"seq_api_key", "signal"}})
+	if err == nil || !strings.Contains(err.Error(), "unsupported resource types seq_signal") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUniqueResourceName(t *testing.T) {
+	used := map[string]bool{}
+	cases := []struct{ in, want string }{
+		{"Ingest (web)", "ingest_web"},
+		{"ingest-web", "ingest_web_2"},
+		{"2024 outage", "seq_2024_outage"},
+		{"???", "seq"},
+		{"", "seq_2"},
+	}
+	for _, tc := range cases {
+		if got := uniqueResourceName(tc.in, used); got != tc.want {
+			t.Fatalf("uniqueResourceName(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
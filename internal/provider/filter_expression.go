@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	frameworkvalidator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/alexdresko/terraform-provider-seq/internal/seqfilter"
+)
+
+var _ basetypes.StringTypable = FilterExpressionType{}
+var _ basetypes.StringValuableWithSemanticEquals = FilterExpression{}
+
+// FilterExpressionType is a string type for Seq filter expressions. Values
+// compare semantically, so formatting-only differences (keyword case,
+// operator spelling, whitespace, redundant parentheses) do not cause diffs.
+type FilterExpressionType struct {
+	basetypes.StringType
+}
+
+func (t FilterExpressionType) String() string {
+	return "FilterExpressionType"
+}
+
+func (t FilterExpressionType) Equal(o attr.Type) bool {
+	other, ok := o.(FilterExpressionType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t FilterExpressionType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return FilterExpression{StringValue: in}, nil
+}
+
+func (t FilterExpressionType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return FilterExpression{StringValue: stringValue}, nil
+}
+
+func (t FilterExpressionType) ValueType(_ context.Context) attr.Value {
+	return FilterExpression{}
+}
+
+// FilterExpression is a Seq filter expression value.
+type FilterExpression struct {
+	basetypes.StringValue
+}
+
+func NewFilterExpressionValue(v string) FilterExpression {
+	return FilterExpression{StringValue: basetypes.NewStringValue(v)}
+}
+
+func NewFilterExpressionNull() FilterExpression {
+	return FilterExpression{StringValue: basetypes.NewStringNull()}
+}
+
+func (v FilterExpression) Type(_ context.Context) attr.Type {
+	return FilterExpressionType{}
+}
+
+func (v FilterExpression) Equal(o attr.Value) bool {
+	other, ok := o.(FilterExpression)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals compares the canonical forms of both filters.
+func (v FilterExpression) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(FilterExpression)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got %T.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return seqfilter.Equivalent(v.ValueString(), newValue.ValueString()), diags
+}
+
+// filterExpressionValidator warns when a filter does not parse as a strict
+// Seq expression. Seq accepts such filters as free-text searches, so this is
+// not an error.
+type filterExpressionValidator struct{}
+
+var _ frameworkvalidator.String = filterExpressionValidator{}
+
+func (v filterExpressionValidator) Description(_ context.Context) string {
+	return "value should be a valid Seq filter expression"
+}
+
+func (v filterExpressionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v filterExpressionValidator) ValidateString(_ context.Context, req frameworkvalidator.StringRequest, resp *frameworkvalidator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := seqfilter.Parse(req.ConfigValue.ValueString()); err != nil {
+		var perr *seqfilter.Error
+		if !errors.As(err, &perr) {
+			return
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Filter is not a strict Seq expression",
+			fmt.Sprintf("The filter could not be parsed (%s). Seq will treat it as a free-text search.", perr.Error()),
+		)
+	}
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/internal/seqfilter"
+)
+
+var _ function.Function = (*AnyOfFunction)(nil)
+
+// AnyOfFunction builds a Seq filter matching a property against several values.
+type AnyOfFunction struct{}
+
+func NewAnyOfFunction() function.Function {
+	return &AnyOfFunction{}
+}
+
+func (f *AnyOfFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "any_of"
+}
+
+func (f *AnyOfFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Builds a Seq filter that matches any of several property values.",
+		Description: "Returns a filter expression such as `Environment in ['prod', 'staging']`. Each value is quoted and escaped.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "The property name, e.g. Environment or @Level.",
+			},
+			function.ListParameter{
+				Name:        "values",
+				Description: "The string values the property may equal.",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *AnyOfFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	var values []string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name, &values))
+	if resp.Error != nil {
+		return
+	}
+
+	if name == "" {
+		resp.Error = function.NewArgumentFuncError(0, "property name must not be empty")
+		return
+	}
+
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, seqfilter.QuoteString(v))
+	}
+
+	expr := seqfilter.PropertyAccessor(name) + " in [" + strings.Join(quoted, ", ") + "]"
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, expr))
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/alexdresko/terraform-provider-seq/internal/seqfilter"
+)
+
+var _ function.Function = (*EscapeStringFunction)(nil)
+
+// EscapeStringFunction escapes a value for use inside a quoted Seq string.
+type EscapeStringFunction struct{}
+
+func NewEscapeStringFunction() function.Function {
+	return &EscapeStringFunction{}
+}
+
+func (f *EscapeStringFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "escape_string"
+}
+
+func (f *EscapeStringFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Escapes a value for use inside a Seq string literal.",
+		Description: "Doubles any single quotes in the value so it can be placed between single quotes in a Seq filter expression, e.g. \"Name = '${provider::seq::escape_string(var.name)}'\".",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "value",
+				Description: "The text to escape.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *EscapeStringFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, seqfilter.EscapeString(value)))
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	"github.com/alexdresko/terraform-provider-seq/internal/seqfilter"
+)
+
+var _ function.Function = (*PropertyEqualsFunction)(nil)
+
+// PropertyEqualsFunction builds a Seq filter comparing a property to a string.
+type PropertyEqualsFunction struct{}
+
+func NewPropertyEqualsFunction() function.Function {
+	return &PropertyEqualsFunction{}
+}
+
+func (f *PropertyEqualsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "property_equals"
+}
+
+func (f *PropertyEqualsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Builds a Seq filter that matches a property value.",
+		Description: "Returns a filter expression such as `Application = 'My App'`. The value is quoted and escaped; property names that are not plain identifiers are accessed through `@Properties['...']`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "The property name, e.g. Application or @Level.",
+			},
+			function.StringParameter{
+				Name:        "value",
+				Description: "The string value the property must equal.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *PropertyEqualsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name, value string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	if name == "" {
+		resp.Error = function.NewArgumentFuncError(0, "property name must not be empty")
+		return
+	}
+
+	expr := seqfilter.PropertyAccessor(name) + " = " + seqfilter.QuoteString(value)
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, expr))
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*TimespanFunction)(nil)
+
+// TimespanFunction converts a duration such as "7d" or "1h30m" into the
+// .NET TimeSpan format Seq uses for retention policies and alert windows.
+type TimespanFunction struct{}
+
+func NewTimespanFunction() function.Function {
+	return &TimespanFunction{}
+}
+
+func (f *TimespanFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "timespan"
+}
+
+func (f *TimespanFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Converts a duration into Seq's TimeSpan format.",
+		Description: "Converts a duration made of d, h, m, s and ms components (e.g. \"7d\", \"1h30m\", \"250ms\") into the `d.hh:mm:ss[.fffffff]` TimeSpan format. The day component is omitted when it is zero, e.g. \"90m\" renders as \"01:30:00\".",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "duration",
+				Description: "The duration to convert.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *TimespanFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	d, err := parseDuration(input)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, formatTimeSpan(d)))
+}
+
+var durationComponent = regexp.MustCompile(`^(\d+(?:\.\d+)?)(ms|d|h|m|s)`)
+
+var durationUnits = map[string]time.Duration{
+	"d":  24 * time.Hour,
+	"h":  time.Hour,
+	"m":  time.Minute,
+	"s":  time.Second,
+	"ms": time.Millisecond,
+}
+
+// parseDuration parses durations like "7d", "1d12h" or "1.5h".
+func parseDuration(s string) (time.Duration, error) {
+	rest := strings.TrimSpace(s)
+	if rest == "" {
+		return 0, fmt.Errorf("duration must not be empty")
+	}
+
+	var total float64
+	for rest != "" {
+		m := durationComponent.FindStringSubmatch(rest)
+		if m == nil {
+			return 0, fmt.Errorf("invalid duration %q: expected components like 7d, 12h, 30m, 15s or 250ms", s)
+		}
+		n, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		total += n * float64(durationUnits[m[2]])
+		rest = rest[len(m[0]):]
+	}
+
+	// float64(math.MaxInt64) rounds up to 2^63, which does not fit in a
+	// Duration, so it is already too large.
+	if total >= math.MaxInt64 {
+		return 0, fmt.Errorf("duration %q is too large", s)
+	}
+	return time.Duration(total), nil
+}
+
+// formatTimeSpan renders d like .NET's constant ("c") TimeSpan format:
+// [d.]hh:mm:ss[.fffffff].
+func formatTimeSpan(d time.Duration) string {
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	d -= minutes * time.Minute
+	seconds := d / time.Second
+	d -= seconds * time.Second
+	// TimeSpan ticks are 100ns.
+	ticks := d / 100
+
+	var sb strings.Builder
+	if days > 0 {
+		fmt.Fprintf(&sb, "%d.", days)
+	}
+	fmt.Fprintf(&sb, "%02d:%02d:%02d", hours, minutes, seconds)
+	if ticks > 0 {
+		fmt.Fprintf(&sb, ".%07d", ticks)
+	}
+	return sb.String()
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func runStringFunction(t *testing.T, f function.Function, args ...attr.Value) (string, *function.FuncError) {
+	t.Helper()
+	req := function.RunRequest{Arguments: function.NewArgumentsData(args)}
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	f.Run(context.Background(), req, resp)
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+	return resp.Result.Value().(types.String).ValueString(), nil
+}
+
+func TestEscapeStringFunction(t *testing.T) {
+	got, err := runStringFunction(t, NewEscapeStringFunction(), types.StringValue("O'Brien's"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "O''Brien''s" {
+		t.Fatalf("unexpected result %q", got)
+	}
+}
+
+func TestPropertyEqualsFunction(t *testing.T) {
+	got, err := runStringFunction(t, NewPropertyEqualsFunction(), types.StringValue("Application"), types.StringValue("Bob's App"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "Application = 'Bob''s App'" {
+		t.Fatalf("unexpected result %q", got)
+	}
+
+	got, _ = runStringFunction(t, NewPropertyEqualsFunction(), types.StringValue("user id"), types.StringValue("7"))
+	if got != "@Properties['user id'] = '7'" {
+		t.Fatalf("unexpected result %q", got)
+	}
+
+	if _, err := runStringFunction(t, NewPropertyEqualsFunction(), types.StringValue(""), types.StringValue("x")); err == nil {
+		t.Fatalf("expected an error for an empty property name")
+	}
+}
+
+func TestAnyOfFunction(t *testing.T) {
+	values := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("prod"), types.StringValue("o'neil")})
+	got, err := runStringFunction(t, NewAnyOfFunction(), types.StringValue("Environment"), values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "Environment in ['prod', 'o''neil']" {
+		t.Fatalf("unexpected result %q", got)
+	}
+}
+
+func TestTimespanFunction(t *testing.T) {
+	cases := map[string]string{
+		"7d":      "7.00:00:00",
+		"1d12h":   "1.12:00:00",
+		"90m":     "01:30:00",
+		"1.5h":    "01:30:00",
+		"45s":     "00:00:45",
+		"1s250ms": "00:00:01.2500000",
+		"0s":      "00:00:00",
+	}
+	for in, want := range cases {
+		got, err := runStringFunction(t, NewTimespanFunction(), types.StringValue(in))
+		if err != nil {
+			t.Fatalf("timespan(%q) unexpected error: %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("timespan(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	// 9223372036854.775808ms is 2^63ns, one more than the largest Duration.
+	for _, in := range []string{"", "7", "1w", "d7", "9223372036854.775808ms"} {
+		if _, err := runStringFunction(t, NewTimespanFunction(), types.StringValue(in)); err == nil {
+			t.Fatalf("timespan(%q) expected an error", in)
+		}
+	}
+}
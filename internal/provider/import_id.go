@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+// importLookupPrefixes are the import id prefixes that name an entity by a
+// property instead of by id, e.g. "title:Ingest".
+var importLookupPrefixes = []string{"title"}
+
+// importStateByLookup implements ImportState for resources identified by a
+// Seq entity id. Besides plain ids it accepts "<prefix>:<value>" for the
+// prefixes in fields, which map to the entity property to match (e.g.
+// "title" to "Title"); the id is resolved by listing the resource group, e.g.
+// seqapi.ResourceAPIKeys.
+func importStateByLookup(ctx context.Context, client *seqapi.Client, resource, noun string, fields map[string]string, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(ctx, client, resource, noun, req.ID, fields)
+	if err != nil {
+		resp.Diagnostics.AddError("Cannot import Seq "+noun, err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// resolveImportID returns the entity id for an import id; see
+// importStateByLookup.
+func resolveImportID(ctx context.Context, c *seqapi.Client, resource, noun, importID string, fields map[string]string) (string, error) {
+	prefix, value, found := strings.Cut(importID, ":")
+	if !found || !isImportLookupPrefix(prefix) {
+		if strings.TrimSpace(importID) == "" {
+			return "", fmt.Errorf("the import id is empty; use the %s id%s", noun, importLookupUsage(fields))
+		}
+		return importID, nil
+	}
+
+	property, ok := fields[strings.ToLower(prefix)]
+	if !ok {
+		return "", fmt.Errorf("%ss cannot be imported by %s; use the %s id%s", noun, strings.ToLower(prefix), noun, importLookupUsage(fields))
+	}
+	if value == "" {
+		return "", fmt.Errorf("the import id %q is missing a %s", importID, strings.ToLower(prefix))
+	}
+	if c == nil {
+		return "", errNotConfigured
+	}
+
+	collection, err := c.ResourcePath(resource)
+	if err != nil {
+		return "", err
+	}
+
+	var matches []string
+	for item, err := range seqapi.ListItems[map[string]any](ctx, c, collection) {
+		if err != nil {
+			return "", fmt.Errorf("list %s to resolve %q: %w", collection, importID, err)
+		}
+		if v, _ := item[property].(string); v == value {
+			id, _ := item["Id"].(string)
+			matches = append(matches, id)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no %s has %s %q", noun, strings.ToLower(prefix), value)
+	case 1:
+		return matches[0], nil
+	default:
+		sort.Strings(matches)
+		return "", fmt.Errorf("%d %ss have %s %q (%s); import by id instead", len(matches), noun, strings.ToLower(prefix), value, strings.Join(matches, ", "))
+	}
+}
+
+func isImportLookupPrefix(prefix string) bool {
+	for _, p := range importLookupPrefixes {
+		if strings.EqualFold(prefix, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// importLookupUsage describes the supported lookup forms as a suffix for
+// error messages, e.g. " or title:<title>".
+func importLookupUsage(fields map[string]string) string {
+	forms := make([]string, 0, len(fields))
+	for prefix := range fields {
+		forms = append(forms, " or "+prefix+":<"+prefix+">")
+	}
+	sort.Strings(forms)
+	return strings.Join(forms, "")
+}
@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+func TestResolveImportID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/apikeys" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"Id": "apikey-1", "Title": "ingest"},
+			{"Id": "apikey-2", "Title": "ci"},
+			{"Id": "apikey-4", "Title": "shared"},
+			{"Id": "apikey-3", "Title": "shared"}
+		]`))
+	}))
+	defer srv.Close()
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})
+	titles := map[string]string{"title": "Title"}
+
+	cases := map[string]struct {
+		importID string
+		fields   map[string]string
+		want     string
+		wantErr  string
+	}{
+		"id":               {importID: "apikey-2", fields: titles, want: "apikey-2"},
+		"title":            {importID: "title:ci", fields: titles, want: "apikey-2"},
+		"prefix case":      {importID: "Title:ingest", fields: titles, want: "apikey-1"},
+		"title with colon": {importID: "title:a:b", fields: titles, wantErr: `no API key has title "a:b"`},
+		"unknown prefix":   {importID: "other:thing", fields: titles, want: "other:thing"},
+		"missing":          {importID: "title:nope", fields: titles, wantErr: `no API key has title "nope"`},
+		"ambiguous":        {importID: "title:shared", fields: titles, wantErr: `2 API keys have title "shared" (apikey-3, apikey-4); import by id instead`},
+		"empty title":      {importID: "title:", fields: titles, wantErr: "missing a title"},
+		"empty id":         {importID: "", fields: titles, wantErr: "use the API key id or title:<title>"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := resolveImportID(context.Background(), c, seqapi.ResourceAPIKeys, "API key", tc.importID, tc.fields)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResolveImportIDWithoutLookups(t *testing.T) {
+	c := newTestClient(t, seqapi.Config{ServerURL: "http://seq.invalid"})
+
+	got, err := resolveImportID(context.Background(), c, seqapi.ResourcePermalinks, "permalink", "permalink-1", nil)
+	if err != nil || got != "permalink-1" {
+		t.Fatalf("got %q, %v", got, err)
+	}
+
+	_, err = resolveImportID(context.Background(), c, seqapi.ResourcePermalinks, "permalink", "title:outage", nil)
+	if err == nil || err.Error() != "permalinks cannot be imported by title; use the permalink id" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestResolveImportIDReportsListFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"Error": "The user does not have the required permission"}`))
+	}))
+	defer srv.Close()
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})
+
+	_, err := resolveImportID(context.Background(), c, seqapi.ResourceAPIKeys, "API key", "title:ci", map[string]string{"title": "Title"})
+	if !seqapi.IsForbidden(err) || !strings.Contains(err.Error(), `resolve "title:ci"`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
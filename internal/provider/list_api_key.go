@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+var _ list.ListResource = (*APIKeyListResource)(nil)
+var _ list.ListResourceWithConfigure = (*APIKeyListResource)(nil)
+
+// APIKeyListResource lists existing API keys for `terraform query`, so they
+// can be imported as seq_api_key resources.
+//
+// Ref: https://datalust.co/docs/server-http-api#api-apikeys
+type APIKeyListResource struct {
+	client *seqapi.Client
+}
+
+type APIKeyListModel struct {
+	TitlePrefix types.String `tfsdk:"title_prefix"`
+	OwnerID     types.String `tfsdk:"owner_id"`
+}
+
+func NewAPIKeyListResource() list.ListResource {
+	return &APIKeyListResource{}
+}
+
+func (r *APIKeyListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_key"
+}
+
+func (r *APIKeyListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the API keys on the Seq server.",
+		Attributes: map[string]schema.Attribute{
+			"title_prefix": schema.StringAttribute{
+				Description: "Only list API keys whose title starts with this prefix (case-sensitive).",
+				Optional:    true,
+			},
+			"owner_id": schema.StringAttribute{
+				Description: "Only list API keys owned by this user id.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *APIKeyListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*seqapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			"Expected *seqapi.Client, got a different type.",
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *APIKeyListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+	if r.client == nil {
+		diags.AddError("Provider not configured", errNotConfigured.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var config APIKeyListModel
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	titlePrefix, ownerID := stringValue(config.TitlePrefix), stringValue(config.OwnerID)
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var listed int64
+		for key, err := range r.client.ListAPIKeys(ctx) {
+			if err != nil {
+				var diags diag.Diagnostics
+				diags.AddError("Failed to list Seq API keys", err.Error())
+				push(list.ListResult{Diagnostics: diags})
+				return
+			}
+			if !strings.HasPrefix(key.Title, titlePrefix) || (ownerID != "" && key.OwnerID != ownerID) {
+				continue
+			}
+			if req.Limit > 0 && listed >= req.Limit {
+				return
+			}
+			listed++
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = key.Title
+			result.Diagnostics.Append(setServerIdentity(ctx, r.client, result.Identity, key.ID)...)
+			if req.IncludeResource {
+				state := APIKeyModel{Permissions: types.SetNull(types.StringType)}
+				applyAPIKeyResponse(&state, key)
+				// Tokens are only shown when a key is created; never surface
+				// one in generated configuration.
+				state.Token = types.StringNull()
+				result.Diagnostics.Append(result.Resource.Set(ctx, &state)...)
+			}
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+var _ list.ListResource = (*PermalinkListResource)(nil)
+var _ list.ListResourceWithConfigure = (*PermalinkListResource)(nil)
+
+// PermalinkListResource lists existing permalinks for `terraform query`, so
+// they can be imported as seq_permalink resources.
+//
+// Ref: https://datalust.co/docs/server-http-api#api-permalinks
+type PermalinkListResource struct {
+	client *seqapi.Client
+}
+
+type PermalinkListModel struct {
+	OwnerID types.String `tfsdk:"owner_id"`
+}
+
+func NewPermalinkListResource() list.ListResource {
+	return &PermalinkListResource{}
+}
+
+func (r *PermalinkListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permalink"
+}
+
+func (r *PermalinkListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the permalinks on the Seq server.",
+		Attributes: map[string]schema.Attribute{
+			"owner_id": schema.StringAttribute{
+				Description: "Only list permalinks created by this user id.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *PermalinkListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*seqapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			"Expected *seqapi.Client, got a different type.",
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *PermalinkListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+	if r.client == nil {
+		diags.AddError("Provider not configured", errNotConfigured.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var config PermalinkListModel
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	ownerID := stringValue(config.OwnerID)
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var listed int64
+		for permalink, err := range r.client.ListPermalinks(ctx) {
+			if err != nil {
+				var diags diag.Diagnostics
+				diags.AddError("Failed to list Seq permalinks", err.Error())
+				push(list.ListResult{Diagnostics: diags})
+				return
+			}
+			if ownerID != "" && (permalink.OwnerID == nil || *permalink.OwnerID != ownerID) {
+				continue
+			}
+			if req.Limit > 0 && listed >= req.Limit {
+				return
+			}
+			listed++
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = permalink.EventID
+			result.Diagnostics.Append(setServerIdentity(ctx, r.client, result.Identity, permalink.ID)...)
+			if req.IncludeResource {
+				var state PermalinkModel
+				applyPermalinkResponse(r.client, &state, permalink)
+				result.Diagnostics.Append(result.Resource.Set(ctx, &state)...)
+			}
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
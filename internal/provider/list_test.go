@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+// runList runs a list resource with the given string config values and
+// returns its results.
+func runList(t *testing.T, lr list.ListResource, r resource.ResourceWithIdentity, config map[string]string, includeResource bool, limit int64) []list.ListResult {
+	t.Helper()
+	ctx := context.Background()
+
+	var configSchema list.ListResourceSchemaResponse
+	lr.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &configSchema)
+	values := map[string]tftypes.Value{}
+	for name := range configSchema.Schema.Attributes {
+		var v any
+		if s, ok := config[name]; ok {
+			v = s
+		}
+		values[name] = tftypes.NewValue(tftypes.String, v)
+	}
+
+	var resourceSchema resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resourceSchema)
+	var identitySchema resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchema)
+
+	req := list.ListRequest{
+		Config: tfsdk.Config{
+			Schema: configSchema.Schema,
+			Raw:    tftypes.NewValue(configSchema.Schema.Type().TerraformType(ctx), values),
+		},
+		IncludeResource:        includeResource,
+		Limit:                  limit,
+		ResourceSchema:         resourceSchema.Schema,
+		ResourceIdentitySchema: identitySchema.IdentitySchema,
+	}
+	var stream list.ListResultsStream
+	lr.List(ctx, req, &stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		results = append(results, result)
+	}
+	return results
+}
+
+func listedIDs(t *testing.T, results []list.ListResult) []string {
+	t.Helper()
+	var ids []string
+	for _, result := range results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+		}
+		var identity ServerIdentityModel
+		if diags := result.Identity.Get(context.Background(), &identity); diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		ids = append(ids, identity.ID.ValueString())
+	}
+	return ids
+}
+
+func TestAPIKeyListResource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"Id": "apikey-1", "Title": "ingest-web", "OwnerId": "user-1", "AssignedPermissions": ["Ingest"], "Token": "secret-token"},
+			{"Id": "apikey-2", "Title": "ci", "AssignedPermissions": ["Read"]},
+			{"Id": "apikey-3", "Title": "ingest-worker", "OwnerId": "user-2", "AssignedPermissions": ["Ingest"],
+			 "InputSettings": {"MinimumLevel": "Warning", "AppliedProperties": [{"Name": "App", "Value": "worker"}]}}
+		]`))
+	}))
+	defer srv.Close()
+	lr := &APIKeyListResource{client: newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})}
+	r := &APIKeyResource{}
+
+	if got := listedIDs(t, runList(t, lr, r, nil, false, 0)); strings.Join(got, ",") != "apikey-1,apikey-2,apikey-3" {
+		t.Fatalf("unexpected ids %v", got)
+	}
+	if got := listedIDs(t, runList(t, lr, r, map[string]string{"title_prefix": "ingest-"}, false, 0)); strings.Join(got, ",") != "apikey-1,apikey-3" {
+		t.Fatalf("unexpected ids for title_prefix %v", got)
+	}
+	if got := listedIDs(t, runList(t, lr, r, map[string]string{"owner_id": "user-2"}, false, 0)); strings.Join(got, ",") != "apikey-3" {
+		t.Fatalf("unexpected ids for owner_id %v", got)
+	}
+	if got := listedIDs(t, runList(t, lr, r, nil, false, 2)); strings.Join(got, ",") != "apikey-1,apikey-2" {
+		t.Fatalf("unexpected ids with limit %v", got)
+	}
+
+	results := runList(t, lr, r, map[string]string{"title_prefix": "ingest-"}, true, 0)
+	listedIDs(t, results)
+	if results[0].DisplayName != "ingest-web" {
+		t.Fatalf("unexpected display name %q", results[0].DisplayName)
+	}
+	var first, second APIKeyModel
+	if diags := results[0].Resource.Get(context.Background(), &first); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if diags := results[1].Resource.Get(context.Background(), &second); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if first.Title.ValueString() != "ingest-web" || !first.Token.IsNull() {
+		t.Fatalf("unexpected resource %+v", first)
+	}
+	if second.MinimumLevel.ValueString() != "Warning" || second.AppliedProperties.IsNull() {
+		t.Fatalf("unexpected resource %+v", second)
+	}
+}
+
+func TestPermalinkListResource(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"Id": "permalink-1", "EventId": "event-1", "OwnerId": "user-1"},
+			{"Id": "permalink-2", "EventId": "event-2"}
+		]`))
+	}))
+	defer srv.Close()
+	lr := &PermalinkListResource{client: newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})}
+	r := &PermalinkResource{}
+
+	if got := listedIDs(t, runList(t, lr, r, map[string]string{"owner_id": "user-1"}, false, 0)); strings.Join(got, ",") != "permalink-1" {
+		t.Fatalf("unexpected ids %v", got)
+	}
+
+	results := runList(t, lr, r, nil, true, 0)
+	listedIDs(t, results)
+	var state PermalinkModel
+	if diags := results[1].Resource.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if results[1].DisplayName != "event-2" || state.EventID.ValueString() != "event-2" || !strings.HasSuffix(state.URL.ValueString(), "permalink=permalink-2") {
+		t.Fatalf("unexpected result %q %+v", results[1].DisplayName, state)
+	}
+}
+
+func TestListResourceReportsListFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+	lr := &APIKeyListResource{client: newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})}
+
+	results := runList(t, lr, &APIKeyResource{}, nil, false, 0)
+	if len(results) != 1 || !results[0].Diagnostics.HasError() || results[0].Diagnostics[0].Summary() != "Failed to list Seq API keys" {
+		t.Fatalf("expected a single error result, got %+v", results)
+	}
+}
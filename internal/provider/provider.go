@@ -3,8 +3,13 @@ package provider
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -15,11 +20,14 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var _ provider.Provider = (*SeqProvider)(nil)
+var _ provider.ProviderWithFunctions = (*SeqProvider)(nil)
+var _ provider.ProviderWithActions = (*SeqProvider)(nil)
+var _ provider.ProviderWithListResources = (*SeqProvider)(nil)
 
 // SeqProvider implements the Terraform provider for Seq.
 //
 // Configuration is passed via provider schema or env vars (see schema descriptions).
-// The provider supplies a configured *Client to resources and datasources.
+// The provider supplies a configured *seqapi.Client to resources and datasources.
 type SeqProvider struct {
 	version string
 }
@@ -31,11 +39,17 @@ type SeqProvider struct {
 // - SEQ_API_KEY
 // - SEQ_INSECURE_SKIP_VERIFY
 // - SEQ_TIMEOUT_SECONDS
+// - SEQ_MAX_CONCURRENT_REQUESTS
+// - SEQ_REQUESTS_PER_SECOND
+// - SEQ_CACHE_READS
 type SeqProviderModel struct {
-	ServerURL          types.String `tfsdk:"server_url"`
-	APIKey             types.String `tfsdk:"api_key"`
-	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
-	TimeoutSeconds     types.Int64  `tfsdk:"timeout_seconds"`
+	ServerURL             types.String  `tfsdk:"server_url"`
+	APIKey                types.String  `tfsdk:"api_key"`
+	InsecureSkipVerify    types.Bool    `tfsdk:"insecure_skip_verify"`
+	TimeoutSeconds        types.Int64   `tfsdk:"timeout_seconds"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	CacheReads            types.Bool    `tfsdk:"cache_reads"`
 }
 
 // New creates a new provider instance.
@@ -71,7 +85,25 @@ func (p *SeqProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *
 				Optional:    true,
 			},
 			"timeout_seconds": schema.Int64Attribute{
-				Description: "HTTP client timeout in seconds. Can be set via SEQ_TIMEOUT_SECONDS.",
+				Description: "HTTP client timeout in seconds. Backup downloads may take longer, but fail if no data arrives for this long; `seq_sql` queries use their own `timeout_seconds`. Can be set via SEQ_TIMEOUT_SECONDS.",
+				Optional:    true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of requests in flight to Seq at once, across all resources and data sources. Unlimited by default. Can be set via SEQ_MAX_CONCURRENT_REQUESTS.",
+				Optional:    true,
+				Validators: []frameworkvalidator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "Maximum number of requests started per second, across all resources and data sources. Unlimited by default. Can be set via SEQ_REQUESTS_PER_SECOND.",
+				Optional:    true,
+				Validators: []frameworkvalidator.Float64{
+					float64validator.AtLeast(0.1),
+				},
+			},
+			"cache_reads": schema.BoolAttribute{
+				Description: "Serve resource reads from one listing of each Seq collection (e.g. /api/apikeys) per Terraform run, instead of one request per resource. Speeds up refreshing large states; writes invalidate the affected listing. Defaults to false. Can be set via SEQ_CACHE_READS.",
 				Optional:    true,
 			},
 		},
@@ -95,16 +127,48 @@ func (p *SeqProvider) Configure(ctx context.Context, req provider.ConfigureReque
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.ActionData = client
+	resp.ListResourceData = client
 }
 
 func (p *SeqProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAPIKeyResource,
+		NewBackupResource,
+		NewBackupSettingsResource,
+		NewPermalinkResource,
+	}
+}
+
+func (p *SeqProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewAPIKeyListResource,
+		NewPermalinkListResource,
 	}
 }
 
 func (p *SeqProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewHealthDataSource,
+		NewAPIKeyMetricsDataSource,
+		NewEventsDataSource,
+		NewSQLDataSource,
+		NewAlertStateDataSource,
+		NewBackupsDataSource,
+	}
+}
+
+func (p *SeqProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewEscapeStringFunction,
+		NewPropertyEqualsFunction,
+		NewAnyOfFunction,
+		NewTimespanFunction,
+	}
+}
+
+func (p *SeqProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewEmitEventAction,
 	}
 }
@@ -3,9 +3,6 @@ package provider
 import (
 	"context"
 	"errors"
-	"fmt"
-	"net/http"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -17,29 +14,47 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	frameworkvalidator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
 )
 
 var _ resource.Resource = (*APIKeyResource)(nil)
 var _ resource.ResourceWithConfigure = (*APIKeyResource)(nil)
 var _ resource.ResourceWithImportState = (*APIKeyResource)(nil)
+var _ resource.ResourceWithIdentity = (*APIKeyResource)(nil)
 
 // APIKeyResource manages Seq API keys via /api/apikeys.
 //
 // Ref: https://datalust.co/docs/server-http-api#api-apikeys
 type APIKeyResource struct {
-	client *Client
+	client *seqapi.Client
 }
 
 // APIKeyModel is the Terraform state model for an API key.
 type APIKeyModel struct {
-	ID                types.String `tfsdk:"id"`
-	Title             types.String `tfsdk:"title"`
-	Token             types.String `tfsdk:"token"`
-	OwnerID           types.String `tfsdk:"owner_id"`
-	Permissions       types.Set    `tfsdk:"permissions"`
-	MinimumLevel      types.String `tfsdk:"minimum_level"`
-	Filter            types.String `tfsdk:"filter"`
-	AppliedProperties types.Map    `tfsdk:"applied_properties"`
+	ID                types.String     `tfsdk:"id"`
+	Title             types.String     `tfsdk:"title"`
+	Token             types.String     `tfsdk:"token"`
+	OwnerID           types.String     `tfsdk:"owner_id"`
+	Permissions       types.Set        `tfsdk:"permissions"`
+	MinimumLevel      types.String     `tfsdk:"minimum_level"`
+	Filter            FilterExpression `tfsdk:"filter"`
+	FilterStrict      types.String     `tfsdk:"filter_strict"`
+	AppliedProperties types.Dynamic    `tfsdk:"applied_properties"`
+}
+
+// apiKeyFieldPaths maps API key request properties to attributes, so Seq
+// validation errors are reported against the offending attribute.
+var apiKeyFieldPaths = map[string]path.Path{
+	"Title":                                path.Root("title"),
+	"OwnerId":                              path.Root("owner_id"),
+	"Permissions":                          path.Root("permissions"),
+	"AssignedPermissions":                  path.Root("permissions"),
+	"InputSettings.MinimumLevel":           path.Root("minimum_level"),
+	"InputSettings.Filter":                 path.Root("filter"),
+	"InputSettings.Filter.Filter":          path.Root("filter"),
+	"InputSettings.Filter.FilterNonStrict": path.Root("filter"),
+	"InputSettings.AppliedProperties":      path.Root("applied_properties"),
 }
 
 func NewAPIKeyResource() resource.Resource {
@@ -48,11 +63,15 @@ func NewAPIKeyResource() resource.Resource {
 
 func (r *APIKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_api_key"
+	// Read rewrites the identity's server_url when the provider's server_url
+	// changes.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 func (r *APIKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Seq API key.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Seq API key id.",
@@ -92,27 +111,42 @@ func (r *APIKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"filter": schema.StringAttribute{
-				Description: "A filter expression to apply to incoming events. Only events matching the filter will be ingested.",
+				Description: "A filter expression to apply to incoming events. Only events matching the filter will be ingested. Non-strict syntax is accepted; Seq converts it to strict syntax (see filter_strict).",
 				Optional:    true,
+				CustomType:  FilterExpressionType{},
+				Validators: []frameworkvalidator.String{
+					filterExpressionValidator{},
+				},
 			},
-			"applied_properties": schema.MapAttribute{
-				Description: "Properties to attach to all events ingested via this API key. These will override any existing properties with the same names.",
+			"filter_strict": schema.StringAttribute{
+				Description: "The strict-syntax form of filter, as converted by Seq.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					// Only recompute the strict form when the filter itself changes.
+					filterStrictPlanModifier{},
+				},
+			},
+			"applied_properties": schema.DynamicAttribute{
+				Description: "Properties to attach to all events ingested via this API key, as an object of property names to values. Strings, numbers, booleans, lists and objects are sent to Seq with their JSON types. These will override any existing properties with the same names.",
 				Optional:    true,
-				ElementType: types.StringType,
 			},
 		},
 	}
 }
 
+func (r *APIKeyResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = serverIdentitySchema("API key")
+}
+
 func (r *APIKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*Client)
+	client, ok := req.ProviderData.(*seqapi.Client)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			"Expected *provider.Client, got a different type.",
+			"Expected *seqapi.Client, got a different type.",
 		)
 		return
 	}
@@ -120,6 +154,9 @@ func (r *APIKeyResource) Configure(_ context.Context, req resource.ConfigureRequ
 }
 
 func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "seq_api_key", "create")
+	defer end(&resp.Diagnostics)
+
 	if !r.checkConfigured(&resp.Diagnostics) {
 		return
 	}
@@ -130,30 +167,21 @@ func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	body, diags := apiKeyRequestBody(ctx, plan, "AssignedPermissions", "")
+	resp.Diagnostics.Append(r.resolveStrictFilter(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, diags := apiKeyFromPlan(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var created apiKeyResponse
-	if err := r.client.doJSON(ctx, http.MethodPost, "/api/apikeys", body, &created); err != nil {
-		// Back-compat: some Seq versions use "Permissions" instead of "AssignedPermissions".
-		var httpErr *HTTPError
-		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusBadRequest && strings.Contains(httpErr.Message, "AssignedPermissions") {
-			legacyBody, legacyDiags := apiKeyRequestBody(ctx, plan, "Permissions", "")
-			resp.Diagnostics.Append(legacyDiags...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-			if err2 := r.client.doJSON(ctx, http.MethodPost, "/api/apikeys", legacyBody, &created); err2 != nil {
-				resp.Diagnostics.AddError("Failed to create Seq API key", err2.Error())
-				return
-			}
-		} else {
-			resp.Diagnostics.AddError("Failed to create Seq API key", err.Error())
-			return
-		}
+	created, err := r.client.CreateAPIKey(ctx, key)
+	if err != nil {
+		resp.Diagnostics.Append(errorDiagnostics("Failed to create Seq API key", err, apiKeyFieldPaths)...)
+		return
 	}
 
 	state := plan
@@ -168,10 +196,17 @@ func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 	if state.Token.IsUnknown() {
 		state.Token = types.StringNull()
 	}
+	if state.FilterStrict.IsUnknown() {
+		state.FilterStrict = types.StringNull()
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setServerIdentity(ctx, r.client, resp.Identity, state.ID.ValueString())...)
 }
 
 func (r *APIKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "seq_api_key", "read")
+	defer end(&resp.Diagnostics)
+
 	if !r.checkConfigured(&resp.Diagnostics) {
 		return
 	}
@@ -187,11 +222,14 @@ func (r *APIKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	var got apiKeyResponse
-	path := "/api/apikeys/" + state.ID.ValueString()
-	if err := r.client.doJSON(ctx, http.MethodGet, path, nil, &got); err != nil {
-		var httpErr *HTTPError
-		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+	resp.Diagnostics.Append(readServerIdentity(ctx, r.client, "API key", req.Identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	got, err := r.client.GetAPIKey(ctx, state.ID.ValueString())
+	if err != nil {
+		if seqapi.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -208,9 +246,13 @@ func (r *APIKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(setServerIdentity(ctx, r.client, resp.Identity, newState.ID.ValueString())...)
 }
 
 func (r *APIKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "seq_api_key", "update")
+	defer end(&resp.Diagnostics)
+
 	if !r.checkConfigured(&resp.Diagnostics) {
 		return
 	}
@@ -229,31 +271,22 @@ func (r *APIKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	apiKeyID := state.ID.ValueString()
-	body, diags := apiKeyRequestBody(ctx, plan, "AssignedPermissions", apiKeyID)
+	resp.Diagnostics.Append(r.resolveStrictFilter(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, diags := apiKeyFromPlan(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	key.ID = state.ID.ValueString()
 
-	var updated apiKeyResponse
-	path := "/api/apikeys/" + apiKeyID
-	if err := r.client.doJSON(ctx, http.MethodPut, path, body, &updated); err != nil {
-		var httpErr *HTTPError
-		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusBadRequest && strings.Contains(httpErr.Message, "AssignedPermissions") {
-			legacyBody, legacyDiags := apiKeyRequestBody(ctx, plan, "Permissions", apiKeyID)
-			resp.Diagnostics.Append(legacyDiags...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-			if err2 := r.client.doJSON(ctx, http.MethodPut, path, legacyBody, &updated); err2 != nil {
-				resp.Diagnostics.AddError("Failed to update Seq API key", err2.Error())
-				return
-			}
-		} else {
-			resp.Diagnostics.AddError("Failed to update Seq API key", err.Error())
-			return
-		}
+	updated, err := r.client.UpdateAPIKey(ctx, key)
+	if err != nil {
+		resp.Diagnostics.Append(errorDiagnostics("Failed to update Seq API key", err, apiKeyFieldPaths)...)
+		return
 	}
 
 	newState := plan
@@ -272,11 +305,18 @@ func (r *APIKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if newState.Token.IsUnknown() {
 		newState.Token = types.StringNull()
 	}
+	if newState.FilterStrict.IsUnknown() {
+		newState.FilterStrict = types.StringNull()
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	resp.Diagnostics.Append(setServerIdentity(ctx, r.client, resp.Identity, newState.ID.ValueString())...)
 }
 
 func (r *APIKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "seq_api_key", "delete")
+	defer end(&resp.Diagnostics)
+
 	if !r.checkConfigured(&resp.Diagnostics) {
 		return
 	}
@@ -291,10 +331,8 @@ func (r *APIKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	path := "/api/apikeys/" + state.ID.ValueString()
-	if err := r.client.doJSON(ctx, http.MethodDelete, path, nil, nil); err != nil {
-		var httpErr *HTTPError
-		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+	if err := r.client.DeleteAPIKey(ctx, state.ID.ValueString()); err != nil {
+		if seqapi.IsNotFound(err) {
 			return
 		}
 		resp.Diagnostics.AddError("Failed to delete Seq API key", err.Error())
@@ -302,101 +340,82 @@ func (r *APIKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 }
 
+// ImportState accepts an API key id or "title:<title>", or an identity from an
+// import block.
 func (r *APIKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-}
-
-type apiKeyResponse struct {
-	ID      string `json:"Id"`
-	Title   string `json:"Title"`
-	Token   string `json:"Token"`
-	OwnerID string `json:"OwnerId"`
-	// Newer Seq versions use AssignedPermissions.
-	AssignedPermissions []string `json:"AssignedPermissions"`
-	// Older Seq versions use Permissions.
-	Permissions   []string           `json:"Permissions"`
-	InputSettings *inputSettingsPart `json:"InputSettings"`
-}
+	ctx, end := traceOperation(ctx, "seq_api_key", "import")
+	defer end(&resp.Diagnostics)
 
-type inputSettingsPart struct {
-	AppliedProperties []eventPropertyPart    `json:"AppliedProperties"`
-	Filter            *descriptiveFilterPart `json:"Filter"`
-	MinimumLevel      *string                `json:"MinimumLevel"`
-}
-
-type eventPropertyPart struct {
-	Name  string `json:"Name"`
-	Value any    `json:"Value"`
-}
-
-type descriptiveFilterPart struct {
-	Filter          string `json:"Filter"`
-	FilterNonStrict string `json:"FilterNonStrict"`
+	if importsByIdentity(req) {
+		importServerIdentity(ctx, r.client, "API key", req, resp)
+		return
+	}
+	importStateByLookup(ctx, r.client, seqapi.ResourceAPIKeys, "API key", map[string]string{"title": "Title"}, req, resp)
 }
 
-func apiKeyRequestBody(ctx context.Context, plan APIKeyModel, permissionsField string, id string) (map[string]any, diag.Diagnostics) {
+// apiKeyFromPlan converts the planned attributes into the API key to create
+// or update, leaving unset attributes out of the request.
+func apiKeyFromPlan(ctx context.Context, plan APIKeyModel) (seqapi.APIKey, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	body := map[string]any{
-		"Title": plan.Title.ValueString(),
-	}
-
-	// Include Id in the body for update operations (Seq requires it to match the URL)
-	if id != "" {
-		body["Id"] = id
+	key := seqapi.APIKey{
+		Title: plan.Title.ValueString(),
 	}
 
-	if !plan.OwnerID.IsNull() && !plan.OwnerID.IsUnknown() && plan.OwnerID.ValueString() != "" {
-		body["OwnerId"] = plan.OwnerID.ValueString()
+	if !plan.OwnerID.IsNull() && !plan.OwnerID.IsUnknown() {
+		key.OwnerID = plan.OwnerID.ValueString()
 	}
 
 	if !plan.Permissions.IsNull() && !plan.Permissions.IsUnknown() {
-		var perms []string
+		perms := []string{}
 		diags.Append(plan.Permissions.ElementsAs(ctx, &perms, false)...)
 		if diags.HasError() {
-			return nil, diags
+			return key, diags
 		}
-		body[permissionsField] = perms
+		key.Permissions = perms
 	}
 
 	// Build InputSettings if any of the input settings fields are set
-	inputSettings := map[string]any{}
+	var inputSettings seqapi.InputSettings
+	set := false
 
 	if !plan.MinimumLevel.IsNull() && !plan.MinimumLevel.IsUnknown() {
-		inputSettings["MinimumLevel"] = plan.MinimumLevel.ValueString()
+		level := plan.MinimumLevel.ValueString()
+		inputSettings.MinimumLevel = &level
+		set = true
 	}
 
 	if !plan.Filter.IsNull() && !plan.Filter.IsUnknown() {
-		inputSettings["Filter"] = map[string]any{
-			"Filter":          plan.Filter.ValueString(),
-			"FilterNonStrict": plan.Filter.ValueString(),
+		// Seq expects strict syntax in Filter; the user's text is kept as FilterNonStrict.
+		strict := plan.Filter.ValueString()
+		if !plan.FilterStrict.IsNull() && !plan.FilterStrict.IsUnknown() && plan.FilterStrict.ValueString() != "" {
+			strict = plan.FilterStrict.ValueString()
 		}
+		inputSettings.Filter = &seqapi.DescriptiveFilter{
+			Filter:          strict,
+			FilterNonStrict: plan.Filter.ValueString(),
+		}
+		set = true
 	}
 
 	if !plan.AppliedProperties.IsNull() && !plan.AppliedProperties.IsUnknown() {
-		var propsMap map[string]string
-		diags.Append(plan.AppliedProperties.ElementsAs(ctx, &propsMap, false)...)
-		if diags.HasError() {
-			return nil, diags
-		}
-		var props []map[string]any
-		for name, value := range propsMap {
-			props = append(props, map[string]any{
-				"Name":  name,
-				"Value": value,
-			})
+		props, err := appliedPropertiesToParts(plan.AppliedProperties)
+		if err != nil {
+			diags.AddAttributeError(path.Root("applied_properties"), "Invalid applied_properties", err.Error())
+			return key, diags
 		}
-		inputSettings["AppliedProperties"] = props
+		inputSettings.AppliedProperties = props
+		set = true
 	}
 
-	if len(inputSettings) > 0 {
-		body["InputSettings"] = inputSettings
+	if set {
+		key.InputSettings = &inputSettings
 	}
 
-	return body, diags
+	return key, diags
 }
 
-func applyAPIKeyResponse(state *APIKeyModel, resp apiKeyResponse) {
+func applyAPIKeyResponse(state *APIKeyModel, resp seqapi.APIKey) {
 	if resp.ID != "" {
 		state.ID = types.StringValue(resp.ID)
 	}
@@ -409,12 +428,8 @@ func applyAPIKeyResponse(state *APIKeyModel, resp apiKeyResponse) {
 	if resp.OwnerID != "" {
 		state.OwnerID = types.StringValue(resp.OwnerID)
 	}
-	perms := resp.AssignedPermissions
-	if perms == nil {
-		perms = resp.Permissions
-	}
-	if perms != nil {
-		state.Permissions = types.SetValueMust(types.StringType, stringSliceToAttrValues(perms))
+	if resp.Permissions != nil {
+		state.Permissions = types.SetValueMust(types.StringType, stringSliceToAttrValues(resp.Permissions))
 	}
 
 	// Apply InputSettings fields
@@ -425,62 +440,90 @@ func applyAPIKeyResponse(state *APIKeyModel, resp apiKeyResponse) {
 			state.MinimumLevel = types.StringNull()
 		}
 
-		if resp.InputSettings.Filter != nil && resp.InputSettings.Filter.Filter != "" {
-			// Prefer FilterNonStrict if available, otherwise use Filter
-			filterValue := resp.InputSettings.Filter.FilterNonStrict
-			if filterValue == "" {
-				filterValue = resp.InputSettings.Filter.Filter
+		if f := resp.InputSettings.Filter; f != nil && (f.Filter != "" || f.FilterNonStrict != "") {
+			strict := f.Filter
+			if strict == "" {
+				strict = f.FilterNonStrict
+			}
+			// Compare on the non-strict form, which is what users write. If Seq
+			// only returns the strict form, keep the configured text as long as
+			// it still corresponds to the strict form we last stored.
+			switch {
+			case f.FilterNonStrict != "":
+				state.Filter = NewFilterExpressionValue(f.FilterNonStrict)
+			case !state.Filter.IsNull() && !state.Filter.IsUnknown() && state.FilterStrict.ValueString() == strict:
+				// Unchanged on the server.
+			default:
+				state.Filter = NewFilterExpressionValue(strict)
 			}
-			state.Filter = types.StringValue(filterValue)
+			state.FilterStrict = types.StringValue(strict)
 		} else {
-			state.Filter = types.StringNull()
+			state.Filter = NewFilterExpressionNull()
+			state.FilterStrict = types.StringNull()
 		}
 
-		if len(resp.InputSettings.AppliedProperties) > 0 {
-			propsMap := make(map[string]attr.Value)
-			for _, prop := range resp.InputSettings.AppliedProperties {
-				// Convert value to string
-				var strValue string
-				switch v := prop.Value.(type) {
-				case string:
-					strValue = v
-				default:
-					// For non-string values, use JSON representation
-					strValue = anyToString(v)
-				}
-				propsMap[prop.Name] = types.StringValue(strValue)
-			}
-			state.AppliedProperties = types.MapValueMust(types.StringType, propsMap)
-		} else {
-			state.AppliedProperties = types.MapNull(types.StringType)
+		// Keep the existing value when it already matches, so the shape the
+		// user configured (object or map) is preserved.
+		if !appliedPropertiesEqual(state.AppliedProperties, resp.InputSettings.AppliedProperties) {
+			state.AppliedProperties = appliedPropertiesFromParts(resp.InputSettings.AppliedProperties)
 		}
 	} else {
 		state.MinimumLevel = types.StringNull()
-		state.Filter = types.StringNull()
-		state.AppliedProperties = types.MapNull(types.StringType)
+		state.Filter = NewFilterExpressionNull()
+		state.FilterStrict = types.StringNull()
+		state.AppliedProperties = types.DynamicNull()
 	}
 }
 
-func anyToString(v any) string {
-	if v == nil {
-		return ""
-	}
-	switch val := v.(type) {
-	case string:
-		return val
-	case float64:
-		// JSON numbers are float64, format without trailing zeros
-		if val == float64(int64(val)) {
-			return fmt.Sprintf("%d", int64(val))
-		}
-		return fmt.Sprintf("%g", val)
-	case bool:
-		if val {
-			return "true"
-		}
-		return "false"
-	default:
-		return fmt.Sprintf("%v", val)
+// resolveStrictFilter asks Seq to convert the planned filter to strict syntax
+// and records the result in plan.FilterStrict.
+func (r *APIKeyResource) resolveStrictFilter(ctx context.Context, plan *APIKeyModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if plan.Filter.IsNull() || plan.Filter.IsUnknown() || plan.Filter.ValueString() == "" {
+		plan.FilterStrict = types.StringNull()
+		return diags
+	}
+
+	strict, err := r.client.ToStrictExpression(ctx, plan.Filter.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("filter"), "Failed to convert filter to strict syntax", err.Error())
+		return diags
+	}
+	plan.FilterStrict = types.StringValue(strict)
+	return diags
+}
+
+// filterStrictPlanModifier keeps the prior filter_strict value while the
+// configured filter is unchanged, and marks it unknown otherwise.
+type filterStrictPlanModifier struct{}
+
+func (m filterStrictPlanModifier) Description(_ context.Context) string {
+	return "Keeps the prior strict filter unless filter changes."
+}
+
+func (m filterStrictPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m filterStrictPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planFilter, stateFilter FilterExpression
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("filter"), &planFilter)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("filter"), &stateFilter)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planFilter.IsNull() {
+		resp.PlanValue = types.StringNull()
+		return
+	}
+	if planFilter.Equal(stateFilter) {
+		resp.PlanValue = req.StateValue
 	}
 }
 
@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+
+	"github.com/alexdresko/terraform-provider-seq/internal/seqfake"
+)
+
+// These tests run Terraform against an in-memory Seq (see internal/seqfake),
+// so they need a terraform binary on PATH (or TF_ACC_TERRAFORM_PATH) but no
+// Seq server.
+
+const fakeAPIKey = "fake-admin-key"
+
+var testProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"seq": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+func fakeProviderConfig(fake *seqfake.Server) string {
+	return fmt.Sprintf(`
+provider "seq" {
+  server_url = %q
+  api_key    = %q
+}
+`, fake.URL, fakeAPIKey)
+}
+
+func apiKeyConfig(fake *seqfake.Server, body string) string {
+	return fakeProviderConfig(fake) + `
+resource "seq_api_key" "test" {
+` + body + `
+}
+`
+}
+
+// fakeAPIKeyID returns the id of the API key in state, for checks against the
+// fake's store.
+func fakeAPIKeyID(s *terraform.State) string {
+	return s.RootModule().Resources["seq_api_key.test"].Primary.ID
+}
+
+func checkFakeAPIKey(fake *seqfake.Server, check func(seqfake.Entity) error) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		key, ok := fake.Get("apikeys", fakeAPIKeyID(s))
+		if !ok {
+			return fmt.Errorf("API key %s not found on the server", fakeAPIKeyID(s))
+		}
+		return check(key)
+	}
+}
+
+func TestAPIKeyResourceLifecycle(t *testing.T) {
+	fake := seqfake.New(t, seqfake.WithAPIKey(fakeAPIKey))
+	fake.SetStrictExpression("Application = 'web'", "Application = 'web' ci")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: apiKeyConfig(fake, `
+  title         = "ingest"
+  permissions   = ["Ingest"]
+  minimum_level = "Warning"
+  filter        = "Application = 'web'"
+  applied_properties = {
+    Environment = "test"
+    Shard       = 3
+  }
+`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("seq_api_key.test", tfjsonpath.New("id"), knownvalue.StringExact("apikey-1")),
+					statecheck.ExpectKnownValue("seq_api_key.test", tfjsonpath.New("token"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("seq_api_key.test", tfjsonpath.New("owner_id"), knownvalue.StringExact(seqfake.DefaultOwnerID)),
+					statecheck.ExpectKnownValue("seq_api_key.test", tfjsonpath.New("permissions"), knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("Ingest")})),
+					statecheck.ExpectKnownValue("seq_api_key.test", tfjsonpath.New("filter_strict"), knownvalue.StringExact("Application = 'web' ci")),
+				},
+				Check: checkFakeAPIKey(fake, func(key seqfake.Entity) error {
+					settings, _ := key["InputSettings"].(map[string]any)
+					if settings["MinimumLevel"] != "Warning" {
+						return fmt.Errorf("MinimumLevel on the server = %v, want Warning", settings["MinimumLevel"])
+					}
+					return nil
+				}),
+			},
+			{
+				Config: apiKeyConfig(fake, `
+  title       = "ingest and read"
+  permissions = ["Ingest", "Read"]
+`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("seq_api_key.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("seq_api_key.test", tfjsonpath.New("title"), knownvalue.StringExact("ingest and read")),
+					statecheck.ExpectKnownValue("seq_api_key.test", tfjsonpath.New("permissions"), knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("Ingest"), knownvalue.StringExact("Read")})),
+					statecheck.ExpectKnownValue("seq_api_key.test", tfjsonpath.New("minimum_level"), knownvalue.Null()),
+					statecheck.ExpectKnownValue("seq_api_key.test", tfjsonpath.New("filter"), knownvalue.Null()),
+				},
+			},
+			{
+				ResourceName:            "seq_api_key.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+			{
+				ResourceName:            "seq_api_key.test",
+				ImportState:             true,
+				ImportStateId:           "title:ingest and read",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if keys := fake.List("apikeys"); len(keys) != 0 {
+				return fmt.Errorf("%d API keys left on the server", len(keys))
+			}
+			return nil
+		},
+	})
+}
+
+func TestAPIKeyResourceDrift(t *testing.T) {
+	fake := seqfake.New(t)
+	config := apiKeyConfig(fake, `
+  title       = "drift"
+  permissions = ["Read"]
+`)
+
+	var id string
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: func(s *terraform.State) error {
+					id = fakeAPIKeyID(s)
+					return nil
+				},
+			},
+			{
+				// Changed outside Terraform: the next apply puts it back.
+				PreConfig: func() {
+					fake.Update("apikeys", id, func(key seqfake.Entity) {
+						key["Title"] = "renamed in the UI"
+						key["AssignedPermissions"] = []any{"Read", "Write"}
+					})
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("seq_api_key.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: checkFakeAPIKey(fake, func(key seqfake.Entity) error {
+					if key["Title"] != "drift" {
+						return fmt.Errorf("title on the server = %v, want drift", key["Title"])
+					}
+					return nil
+				}),
+			},
+			{
+				// Deleted outside Terraform: the next apply recreates it.
+				PreConfig: func() {
+					fake.Delete("apikeys", id)
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("seq_api_key.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: func(s *terraform.State) error {
+					if got := fakeAPIKeyID(s); got == id {
+						return fmt.Errorf("expected a new API key, got the deleted id %s", id)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAPIKeyResourceLegacyPermissions(t *testing.T) {
+	fake := seqfake.New(t, seqfake.WithVersion("2020.5.4778"))
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The fake only accepts permissions in the field the server
+				// version uses, so a wrong field would leave the key without
+				// permissions and fail the apply.
+				Config: apiKeyConfig(fake, `
+  title       = "legacy"
+  permissions = ["Ingest", "Read"]
+`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("seq_api_key.test", tfjsonpath.New("permissions"), knownvalue.SetExact([]knownvalue.Check{
+						knownvalue.StringExact("Ingest"),
+						knownvalue.StringExact("Read"),
+					})),
+				},
+			},
+			{
+				ResourceName:            "seq_api_key.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+		},
+	})
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithUpgradeState = (*APIKeyResource)(nil)
+
+// apiKeyModelV0 is the state model before applied_properties became dynamic.
+type apiKeyModelV0 struct {
+	ID                types.String `tfsdk:"id"`
+	Title             types.String `tfsdk:"title"`
+	Token             types.String `tfsdk:"token"`
+	OwnerID           types.String `tfsdk:"owner_id"`
+	Permissions       types.Set    `tfsdk:"permissions"`
+	MinimumLevel      types.String `tfsdk:"minimum_level"`
+	Filter            types.String `tfsdk:"filter"`
+	FilterStrict      types.String `tfsdk:"filter_strict"`
+	AppliedProperties types.Map    `tfsdk:"applied_properties"`
+}
+
+// apiKeySchemaV0 describes version 0 state, where applied_properties was a
+// map(string). Released v0 state has no filter_strict; it is included, and
+// read as null when absent, because development builds briefly wrote it
+// before the schema moved to version 1.
+func apiKeySchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                 schema.StringAttribute{Computed: true},
+			"title":              schema.StringAttribute{Required: true},
+			"token":              schema.StringAttribute{Computed: true, Sensitive: true},
+			"owner_id":           schema.StringAttribute{Optional: true, Computed: true},
+			"permissions":        schema.SetAttribute{Optional: true, ElementType: types.StringType},
+			"minimum_level":      schema.StringAttribute{Optional: true},
+			"filter":             schema.StringAttribute{Optional: true},
+			"filter_strict":      schema.StringAttribute{Computed: true},
+			"applied_properties": schema.MapAttribute{Optional: true, ElementType: types.StringType},
+		},
+	}
+}
+
+func (r *APIKeyResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   apiKeySchemaV0(),
+			StateUpgrader: upgradeAPIKeyStateV0,
+		},
+	}
+}
+
+// upgradeAPIKeyStateV0 converts map(string) applied_properties into a dynamic
+// object of strings, which matches configurations written as
+// applied_properties = { Name = "value" }.
+func upgradeAPIKeyStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior apiKeyModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded := APIKeyModel{
+		ID:                prior.ID,
+		Title:             prior.Title,
+		Token:             prior.Token,
+		OwnerID:           prior.OwnerID,
+		Permissions:       prior.Permissions,
+		MinimumLevel:      prior.MinimumLevel,
+		Filter:            FilterExpression{StringValue: prior.Filter},
+		FilterStrict:      prior.FilterStrict,
+		AppliedProperties: types.DynamicNull(),
+	}
+
+	if !prior.AppliedProperties.IsNull() && !prior.AppliedProperties.IsUnknown() {
+		elems := prior.AppliedProperties.Elements()
+		attrTypes := make(map[string]attr.Type, len(elems))
+		for name := range elems {
+			attrTypes[name] = types.StringType
+		}
+		obj, diags := types.ObjectValue(attrTypes, elems)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		upgraded.AppliedProperties = types.DynamicValue(obj)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+}
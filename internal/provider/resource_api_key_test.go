@@ -2,12 +2,23 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
 )
 
 func TestClientAddsAPIKeyHeader(t *testing.T) {
@@ -20,7 +31,7 @@ func TestClientAddsAPIKeyHeader(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	c := &Client{baseURL: mustParseURL(srv.URL), apiKey: "abc", http: srv.Client()}
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client(), APIKey: "abc"})
 	if err := c.Ping(context.Background()); err != nil {
 		t.Fatalf("Ping() error: %v", err)
 	}
@@ -29,102 +40,88 @@ func TestClientAddsAPIKeyHeader(t *testing.T) {
 	}
 }
 
-func TestAPIKeyRequestBody(t *testing.T) {
+func TestAPIKeyFromPlan(t *testing.T) {
 	m := APIKeyModel{
 		Title:       types.StringValue("x"),
 		OwnerID:     types.StringValue("owner"),
 		Permissions: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("Read"), types.StringValue("Write")}),
 	}
-	body, diags := apiKeyRequestBody(context.Background(), m, "AssignedPermissions", "")
+	key, diags := apiKeyFromPlan(context.Background(), m)
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics")
 	}
-	if body["Title"].(string) != "x" {
-		t.Fatalf("title mismatch")
-	}
-	if _, ok := body["AssignedPermissions"]; !ok {
-		t.Fatalf("expected AssignedPermissions in request body")
-	}
-	// ID should not be present when empty
-	if _, ok := body["Id"]; ok {
-		t.Fatalf("expected Id to be absent for create operations")
+	if key.Title != "x" || key.OwnerID != "owner" {
+		t.Fatalf("title/owner mismatch: %+v", key)
 	}
-}
-
-func TestAPIKeyRequestBodyWithID(t *testing.T) {
-	m := APIKeyModel{
-		Title:       types.StringValue("x"),
-		OwnerID:     types.StringValue("owner"),
-		Permissions: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("Read")}),
+	if len(key.Permissions) != 2 {
+		t.Fatalf("expected 2 permissions, got %v", key.Permissions)
 	}
-	body, diags := apiKeyRequestBody(context.Background(), m, "AssignedPermissions", "apikey-123")
-	if diags.HasError() {
-		t.Fatalf("unexpected diagnostics")
+	// The id is only set for updates, from state.
+	if key.ID != "" {
+		t.Fatalf("expected no id, got %q", key.ID)
 	}
-	// ID should be present for update operations
-	if id, ok := body["Id"].(string); !ok || id != "apikey-123" {
-		t.Fatalf("expected Id 'apikey-123' in request body for update, got %v", body["Id"])
+	if key.InputSettings != nil {
+		t.Fatalf("expected no input settings, got %+v", key.InputSettings)
 	}
 }
 
-func TestAPIKeyRequestBodyWithInputSettings(t *testing.T) {
+func TestAPIKeyFromPlanWithInputSettings(t *testing.T) {
 	m := APIKeyModel{
 		Title:        types.StringValue("test-key"),
 		OwnerID:      types.StringNull(),
 		Permissions:  types.SetValueMust(types.StringType, []attr.Value{types.StringValue("Ingest")}),
 		MinimumLevel: types.StringValue("Warning"),
-		Filter:       types.StringValue("@Level = 'Error'"),
-		AppliedProperties: types.MapValueMust(types.StringType, map[string]attr.Value{
+		Filter:       NewFilterExpressionValue("@Level = 'Error'"),
+		AppliedProperties: types.DynamicValue(types.MapValueMust(types.StringType, map[string]attr.Value{
 			"Application": types.StringValue("MyApp"),
 			"Environment": types.StringValue("Production"),
-		}),
+		})),
 	}
-	body, diags := apiKeyRequestBody(context.Background(), m, "AssignedPermissions", "")
+	key, diags := apiKeyFromPlan(context.Background(), m)
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics: %v", diags)
 	}
 
-	inputSettings, ok := body["InputSettings"].(map[string]any)
-	if !ok {
-		t.Fatalf("expected InputSettings in request body")
+	inputSettings := key.InputSettings
+	if inputSettings == nil {
+		t.Fatalf("expected InputSettings")
 	}
 
-	if inputSettings["MinimumLevel"] != "Warning" {
-		t.Fatalf("expected MinimumLevel to be 'Warning', got %v", inputSettings["MinimumLevel"])
+	if inputSettings.MinimumLevel == nil || *inputSettings.MinimumLevel != "Warning" {
+		t.Fatalf("expected MinimumLevel to be 'Warning', got %v", inputSettings.MinimumLevel)
 	}
 
-	filter, ok := inputSettings["Filter"].(map[string]any)
-	if !ok {
+	filter := inputSettings.Filter
+	if filter == nil {
 		t.Fatalf("expected Filter in InputSettings")
 	}
-	if filter["Filter"] != "@Level = 'Error'" {
-		t.Fatalf("expected Filter to be '@Level = 'Error'', got %v", filter["Filter"])
+	if filter.Filter != "@Level = 'Error'" {
+		t.Fatalf("expected Filter to be '@Level = 'Error'', got %v", filter.Filter)
 	}
-
-	props, ok := inputSettings["AppliedProperties"].([]map[string]any)
-	if !ok {
-		t.Fatalf("expected AppliedProperties in InputSettings")
+	if filter.FilterNonStrict != "@Level = 'Error'" {
+		t.Fatalf("expected FilterNonStrict to be '@Level = 'Error'', got %v", filter.FilterNonStrict)
 	}
-	if len(props) != 2 {
-		t.Fatalf("expected 2 applied properties, got %d", len(props))
+
+	if len(inputSettings.AppliedProperties) != 2 {
+		t.Fatalf("expected 2 applied properties, got %d", len(inputSettings.AppliedProperties))
 	}
 }
 
 func TestApplyAPIKeyResponseWithInputSettings(t *testing.T) {
 	minLevel := "Error"
-	resp := apiKeyResponse{
-		ID:                  "apikey-123",
-		Title:               "Test Key",
-		Token:               "secret-token",
-		OwnerID:             "user-1",
-		AssignedPermissions: []string{"Ingest", "Read"},
-		InputSettings: &inputSettingsPart{
+	resp := seqapi.APIKey{
+		ID:          "apikey-123",
+		Title:       "Test Key",
+		Token:       "secret-token",
+		OwnerID:     "user-1",
+		Permissions: []string{"Ingest", "Read"},
+		InputSettings: &seqapi.InputSettings{
 			MinimumLevel: &minLevel,
-			Filter: &descriptiveFilterPart{
+			Filter: &seqapi.DescriptiveFilter{
 				Filter:          "@Level = 'Error'",
 				FilterNonStrict: "Level = Error",
 			},
-			AppliedProperties: []eventPropertyPart{
+			AppliedProperties: []seqapi.EventProperty{
 				{Name: "Application", Value: "TestApp"},
 				{Name: "Version", Value: "1.0"},
 			},
@@ -144,26 +141,25 @@ func TestApplyAPIKeyResponseWithInputSettings(t *testing.T) {
 	if state.Filter.ValueString() != "Level = Error" {
 		t.Fatalf("expected Filter 'Level = Error', got %q", state.Filter.ValueString())
 	}
+	if state.FilterStrict.ValueString() != "@Level = 'Error'" {
+		t.Fatalf("expected FilterStrict \"@Level = 'Error'\", got %q", state.FilterStrict.ValueString())
+	}
 	if state.AppliedProperties.IsNull() {
 		t.Fatalf("expected AppliedProperties to not be null")
 	}
 
-	var propsMap map[string]string
-	diags := state.AppliedProperties.ElementsAs(context.Background(), &propsMap, false)
-	if diags.HasError() {
-		t.Fatalf("failed to get AppliedProperties: %v", diags)
-	}
-	if propsMap["Application"] != "TestApp" {
-		t.Fatalf("expected Application 'TestApp', got %q", propsMap["Application"])
+	props := state.AppliedProperties.UnderlyingValue().(types.Object).Attributes()
+	if props["Application"] != types.StringValue("TestApp") {
+		t.Fatalf("expected Application 'TestApp', got %v", props["Application"])
 	}
 }
 
 func TestApplyAPIKeyResponseWithEmptyInputSettings(t *testing.T) {
-	resp := apiKeyResponse{
-		ID:                  "apikey-456",
-		Title:               "Simple Key",
-		AssignedPermissions: []string{"Ingest"},
-		InputSettings:       nil,
+	resp := seqapi.APIKey{
+		ID:            "apikey-456",
+		Title:         "Simple Key",
+		Permissions:   []string{"Ingest"},
+		InputSettings: nil,
 	}
 
 	state := &APIKeyModel{}
@@ -179,3 +175,416 @@ func TestApplyAPIKeyResponseWithEmptyInputSettings(t *testing.T) {
 		t.Fatalf("expected AppliedProperties to be null")
 	}
 }
+
+func TestAPIKeyFromPlanUsesStrictFilter(t *testing.T) {
+	m := APIKeyModel{
+		Title:        types.StringValue("x"),
+		Filter:       NewFilterExpressionValue("Level = Error"),
+		FilterStrict: types.StringValue("Level = 'Error'"),
+	}
+	key, diags := apiKeyFromPlan(context.Background(), m)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	filter := key.InputSettings.Filter
+	if filter.Filter != "Level = 'Error'" {
+		t.Fatalf("expected strict Filter, got %v", filter.Filter)
+	}
+	if filter.FilterNonStrict != "Level = Error" {
+		t.Fatalf("expected configured FilterNonStrict, got %v", filter.FilterNonStrict)
+	}
+}
+
+func TestApplyAPIKeyResponseWithStrictOnlyFilter(t *testing.T) {
+	resp := seqapi.APIKey{
+		ID: "apikey-789",
+		InputSettings: &seqapi.InputSettings{
+			Filter: &seqapi.DescriptiveFilter{Filter: "Level = 'Error'"},
+		},
+	}
+
+	// The configured text is kept while the strict form is unchanged.
+	state := &APIKeyModel{
+		Filter:       NewFilterExpressionValue("Level = Error"),
+		FilterStrict: types.StringValue("Level = 'Error'"),
+	}
+	applyAPIKeyResponse(state, resp)
+	if state.Filter.ValueString() != "Level = Error" {
+		t.Fatalf("expected Filter 'Level = Error', got %q", state.Filter.ValueString())
+	}
+	if state.FilterStrict.ValueString() != "Level = 'Error'" {
+		t.Fatalf("expected FilterStrict \"Level = 'Error'\", got %q", state.FilterStrict.ValueString())
+	}
+
+	// A strict form changed outside Terraform surfaces as drift.
+	resp.InputSettings.Filter.Filter = "Level = 'Fatal'"
+	applyAPIKeyResponse(state, resp)
+	if state.Filter.ValueString() != "Level = 'Fatal'" {
+		t.Fatalf("expected Filter \"Level = 'Fatal'\", got %q", state.Filter.ValueString())
+	}
+}
+
+func TestClientToStrictExpression(t *testing.T) {
+	var gotFuzzy string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/expressions/to-strict" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		gotFuzzy = r.URL.Query().Get("fuzzy")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"StrictExpression":"Level = 'Error'","MatchedAsText":false}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})
+	strict, err := c.ToStrictExpression(context.Background(), "Level = Error")
+	if err != nil {
+		t.Fatalf("ToStrictExpression() error: %v", err)
+	}
+	if gotFuzzy != "Level = Error" {
+		t.Fatalf("expected fuzzy query parameter, got %q", gotFuzzy)
+	}
+	if strict != "Level = 'Error'" {
+		t.Fatalf("unexpected strict expression %q", strict)
+	}
+}
+
+func TestFilterExpressionSemanticEquals(t *testing.T) {
+	prior := NewFilterExpressionValue("@Level == 'Error' || @Level == 'Fatal'")
+
+	equal, diags := prior.StringSemanticEquals(context.Background(), NewFilterExpressionValue("@Level = 'Error' or @Level = 'Fatal'"))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !equal {
+		t.Fatalf("expected filters to be semantically equal")
+	}
+
+	equal, _ = prior.StringSemanticEquals(context.Background(), NewFilterExpressionValue("@Level = 'Error'"))
+	if equal {
+		t.Fatalf("expected filters to differ")
+	}
+}
+
+func TestAPIKeyFromPlanWithTypedAppliedProperties(t *testing.T) {
+	m := APIKeyModel{
+		Title: types.StringValue("typed"),
+		AppliedProperties: types.DynamicValue(types.ObjectValueMust(
+			map[string]attr.Type{"Port": types.NumberType, "Enabled": types.BoolType, "Name": types.StringType},
+			map[string]attr.Value{
+				"Port":    types.NumberValue(big.NewFloat(8080)),
+				"Enabled": types.BoolValue(true),
+				"Name":    types.StringValue("api"),
+			},
+		)),
+	}
+	key, diags := apiKeyFromPlan(context.Background(), m)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	b, err := json.Marshal(key.InputSettings.AppliedProperties)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `[{"Name":"Enabled","Value":true},{"Name":"Name","Value":"api"},{"Name":"Port","Value":8080}]`
+	if string(b) != want {
+		t.Fatalf("unexpected AppliedProperties JSON:\n got %s\nwant %s", b, want)
+	}
+}
+
+func TestApplyAPIKeyResponseWithTypedAppliedProperties(t *testing.T) {
+	resp := seqapi.APIKey{
+		ID: "apikey-1",
+		InputSettings: &seqapi.InputSettings{
+			AppliedProperties: []seqapi.EventProperty{
+				{Name: "Port", Value: float64(8080)},
+				{Name: "Enabled", Value: true},
+			},
+		},
+	}
+
+	state := &APIKeyModel{AppliedProperties: types.DynamicNull()}
+	applyAPIKeyResponse(state, resp)
+
+	props := state.AppliedProperties.UnderlyingValue().(types.Object).Attributes()
+	if port, ok := props["Port"].(types.Number); !ok || port.ValueBigFloat().Cmp(big.NewFloat(8080)) != 0 {
+		t.Fatalf("expected numeric Port 8080, got %v", props["Port"])
+	}
+	if props["Enabled"] != types.BoolValue(true) {
+		t.Fatalf("expected boolean Enabled true, got %v", props["Enabled"])
+	}
+
+	// A configured map with the same contents is kept as-is.
+	configured := types.DynamicValue(types.MapValueMust(types.NumberType, map[string]attr.Value{
+		"Port":    types.NumberValue(big.NewFloat(8080)),
+		"Enabled": types.NumberValue(big.NewFloat(1)),
+	}))
+	state.AppliedProperties = configured
+	resp.InputSettings.AppliedProperties[1].Value = float64(1)
+	applyAPIKeyResponse(state, resp)
+	if !state.AppliedProperties.Equal(configured) {
+		t.Fatalf("expected configured applied_properties to be preserved, got %v", state.AppliedProperties)
+	}
+}
+
+func TestUpgradeAPIKeyStateV0(t *testing.T) {
+	ctx := context.Background()
+
+	priorSchema := apiKeySchemaV0()
+	prior := tfsdk.State{Schema: *priorSchema, Raw: tftypes.NewValue(priorSchema.Type().TerraformType(ctx), nil)}
+	diags := prior.Set(ctx, &apiKeyModelV0{
+		ID:          types.StringValue("apikey-1"),
+		Title:       types.StringValue("legacy"),
+		Token:       types.StringValue("secret"),
+		OwnerID:     types.StringNull(),
+		Permissions: types.SetNull(types.StringType),
+		Filter:      types.StringValue("@Level = 'Error'"),
+		AppliedProperties: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"Application": types.StringValue("MyApp"),
+		}),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var schemaResp resource.SchemaResponse
+	NewAPIKeyResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	upgradeAPIKeyStateV0(ctx, resource.UpgradeStateRequest{State: &prior}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var upgraded APIKeyModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &upgraded)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if upgraded.Token.ValueString() != "secret" || upgraded.Filter.ValueString() != "@Level = 'Error'" {
+		t.Fatalf("expected token and filter to carry over, got %v / %v", upgraded.Token, upgraded.Filter)
+	}
+	props := upgraded.AppliedProperties.UnderlyingValue().(types.Object).Attributes()
+	if props["Application"] != types.StringValue("MyApp") {
+		t.Fatalf("expected Application 'MyApp', got %v", props["Application"])
+	}
+}
+
+func TestUpgradeAPIKeyStateV0FromReleasedState(t *testing.T) {
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// State as written by releases before version 1: no filter_strict and
+	// applied_properties as a map of strings.
+	raw := `{"id":"apikey-1","title":"legacy","token":"secret","owner_id":null,"permissions":["Ingest"],` +
+		`"minimum_level":"Warning","filter":"@Level = 'Error'","applied_properties":{"Application":"MyApp"}}`
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "seq_api_key",
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: []byte(raw)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	upgraded, err := resp.UpgradedState.Unmarshal(schemaResp.ResourceSchemas["seq_api_key"].ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attrs map[string]tftypes.Value
+	if err := upgraded.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+	var title, filter string
+	if err := attrs["title"].As(&title); err != nil || title != "legacy" {
+		t.Fatalf("unexpected title %v", attrs["title"])
+	}
+	if err := attrs["filter"].As(&filter); err != nil || filter != "@Level = 'Error'" {
+		t.Fatalf("unexpected filter %v", attrs["filter"])
+	}
+	if !attrs["filter_strict"].IsNull() {
+		t.Fatalf("expected null filter_strict, got %v", attrs["filter_strict"])
+	}
+	var props map[string]tftypes.Value
+	if err := attrs["applied_properties"].As(&props); err != nil {
+		t.Fatal(err)
+	}
+	var app string
+	if err := props["Application"].As(&app); err != nil || app != "MyApp" {
+		t.Fatalf("unexpected applied_properties %v", attrs["applied_properties"])
+	}
+}
+
+func newAPIKeyIdentity(t *testing.T, identity *ServerIdentityModel) *tfsdk.ResourceIdentity {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.IdentitySchemaResponse
+	(&APIKeyResource{}).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &schemaResp)
+	out := &tfsdk.ResourceIdentity{Schema: schemaResp.IdentitySchema, Raw: tftypes.NewValue(schemaResp.IdentitySchema.Type().TerraformType(ctx), nil)}
+	if identity != nil {
+		if diags := out.Set(ctx, identity); diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+	}
+	return out
+}
+
+func newAPIKeyState(t *testing.T, id string) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	NewAPIKeyResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := state.SetAttribute(ctx, path.Root("id"), id); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return state
+}
+
+func TestAPIKeyReadSetsIdentity(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"Id": "apikey-1", "Title": "ingest"}`))
+	}))
+	defer srv.Close()
+	r := &APIKeyResource{client: newTestClient(t, seqapi.Config{ServerURL: srv.URL + "/", HTTPClient: srv.Client()})}
+
+	state := newAPIKeyState(t, "apikey-1")
+	resp := &resource.ReadResponse{State: state, Identity: newAPIKeyIdentity(t, nil)}
+	r.Read(ctx, resource.ReadRequest{State: state, Identity: newAPIKeyIdentity(t, nil)}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var identity ServerIdentityModel
+	resp.Diagnostics.Append(resp.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if identity.ServerURL.ValueString() != srv.URL || identity.ID.ValueString() != "apikey-1" {
+		t.Fatalf("unexpected identity %+v", identity)
+	}
+}
+
+func TestAPIKeyReadRewritesIdentityFromAnotherServer(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"Id": "apikey-1"}`))
+	}))
+	defer srv.Close()
+	r := &APIKeyResource{client: newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})}
+
+	state := newAPIKeyState(t, "apikey-1")
+	prior := newAPIKeyIdentity(t, &ServerIdentityModel{
+		ServerURL: types.StringValue("https://seq.other.example"),
+		ID:        types.StringValue("apikey-1"),
+	})
+	resp := &resource.ReadResponse{State: state, Identity: prior}
+	r.Read(ctx, resource.ReadRequest{State: state, Identity: prior}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != "Seq API key identity names a different server" {
+		t.Fatalf("expected server mismatch warning, got %v", resp.Diagnostics)
+	}
+
+	var identity ServerIdentityModel
+	resp.Diagnostics.Append(resp.Identity.Get(ctx, &identity)...)
+	if identity.ServerURL.ValueString() != srv.URL {
+		t.Fatalf("expected identity rewritten to %s, got %+v", srv.URL, identity)
+	}
+}
+
+func TestAPIKeyReadAcceptsEquivalentServerURL(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"Id": "apikey-1"}`))
+	}))
+	defer srv.Close()
+	r := &APIKeyResource{client: newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})}
+
+	state := newAPIKeyState(t, "apikey-1")
+	prior := newAPIKeyIdentity(t, &ServerIdentityModel{
+		ServerURL: types.StringValue(strings.ToUpper(srv.URL) + "/"),
+		ID:        types.StringValue("apikey-1"),
+	})
+	resp := &resource.ReadResponse{State: state, Identity: prior}
+	r.Read(ctx, resource.ReadRequest{State: state, Identity: prior}, resp)
+	if len(resp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
+
+func TestSameServerURL(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"https://seq.example.com", "https://seq.example.com/", true},
+		{"HTTPS://Seq.Example.com", "https://seq.example.com", true},
+		{"https://seq.example.com:443", "https://seq.example.com", true},
+		{"http://seq.example.com:80/", "http://seq.example.com", true},
+		{"http://[::1]:80", "http://[::1]", true},
+		{"https://seq.example.com/seq/", "https://seq.example.com/seq", true},
+		{"http://seq.example.com:5341", "http://seq.example.com", false},
+		{"http://seq.example.com", "https://seq.example.com", false},
+		{"https://seq.example.com/Seq", "https://seq.example.com/seq", false},
+		{"https://seq.other.example", "https://seq.example.com", false},
+	}
+	for _, tc := range cases {
+		if got := sameServerURL(tc.a, tc.b); got != tc.want {
+			t.Errorf("sameServerURL(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestAPIKeyImportStateByIdentity(t *testing.T) {
+	ctx := context.Background()
+	r := &APIKeyResource{client: newTestClient(t, seqapi.Config{ServerURL: "https://seq.example.com/"})}
+
+	cases := map[string]struct {
+		serverURL types.String
+		wantErr   bool
+	}{
+		"server omitted":          {serverURL: types.StringNull()},
+		"same server":             {serverURL: types.StringValue("https://seq.example.com")},
+		"same server, other form": {serverURL: types.StringValue("HTTPS://Seq.Example.com:443/")},
+		"other server":            {serverURL: types.StringValue("https://seq.other.example"), wantErr: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			identity := newAPIKeyIdentity(t, &ServerIdentityModel{ServerURL: tc.serverURL, ID: types.StringValue("apikey-7")})
+			resp := &resource.ImportStateResponse{State: newAPIKeyState(t, ""), Identity: identity}
+			r.ImportState(ctx, resource.ImportStateRequest{Identity: identity}, resp)
+			if tc.wantErr {
+				if !resp.Diagnostics.HasError() {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			var id types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			if id.ValueString() != "apikey-7" {
+				t.Fatalf("expected id apikey-7, got %v", id)
+			}
+		})
+	}
+}
@@ -1,11 +1,16 @@
 package provider
 
-import "net/url"
+import (
+	"testing"
 
-func mustParseURL(raw string) *url.URL {
-	u, err := url.Parse(raw)
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+func newTestClient(t testing.TB, cfg seqapi.Config) *seqapi.Client {
+	t.Helper()
+	c, err := seqapi.New(cfg)
 	if err != nil {
-		panic(err)
+		t.Fatal(err)
 	}
-	return u
+	return c
 }
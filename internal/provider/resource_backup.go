@@ -0,0 +1,331 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+var _ resource.Resource = (*BackupResource)(nil)
+var _ resource.ResourceWithConfigure = (*BackupResource)(nil)
+
+// BackupResource requests a Seq backup via /api/backups and optionally
+// downloads it.
+//
+// Ref: https://datalust.co/docs/server-http-api#api-backups
+type BackupResource struct {
+	client *seqapi.Client
+}
+
+type BackupModel struct {
+	ID           types.String `tfsdk:"id"`
+	Triggers     types.Map    `tfsdk:"triggers"`
+	DownloadPath types.String `tfsdk:"download_path"`
+	Filename     types.String `tfsdk:"filename"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	SizeBytes    types.Int64  `tfsdk:"size_bytes"`
+	SHA256       types.String `tfsdk:"sha256"`
+}
+
+func NewBackupResource() resource.Resource {
+	return &BackupResource{}
+}
+
+func (r *BackupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backup"
+}
+
+func (r *BackupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Takes a Seq backup and optionally downloads it. Destroying the resource leaves the backup (and any downloaded file) in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Seq backup id.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that, when changed, cause a new backup to be taken (e.g. the Seq version being upgraded to).",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"download_path": schema.StringAttribute{
+				Description: "Local file to download the backup to. Parent directories are created as needed. Changing it downloads the same backup again, as does deleting or changing the file: each refresh re-checks its SHA-256 checksum.",
+				Optional:    true,
+			},
+			"filename": schema.StringAttribute{
+				Description: "Backup file name on the Seq server.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Time (RFC 3339) the backup was created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size_bytes": schema.Int64Attribute{
+				Description: "Backup size in bytes, as reported by Seq.",
+				Computed:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "Hex-encoded SHA-256 checksum of the downloaded file. Null unless download_path is set.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *BackupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*seqapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *seqapi.Client, got a different type.",
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *BackupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "seq_backup", "create")
+	defer end(&resp.Diagnostics)
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errNotConfigured.Error())
+		return
+	}
+
+	var plan BackupModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateBackup(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create Seq backup", err.Error())
+		return
+	}
+
+	state := plan
+	applyBackupResponse(&state, created)
+	state.SHA256 = types.StringNull()
+
+	// Save the backup id before downloading so a failed download does not
+	// orphan the backup.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if downloadPath := stringValue(plan.DownloadPath); downloadPath != "" {
+		sum, err := r.downloadBackup(ctx, created.ID, downloadPath)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to download Seq backup", err.Error())
+			return
+		}
+		state.SHA256 = types.StringValue(sum)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BackupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "seq_backup", "read")
+	defer end(&resp.Diagnostics)
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errNotConfigured.Error())
+		return
+	}
+
+	var state BackupModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ID.IsNull() || state.ID.IsUnknown() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	downloadOK, diags := checkDownloadedBackup(state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	got, err := r.client.GetBackup(ctx, state.ID.ValueString())
+	if err != nil {
+		if seqapi.IsNotFound(err) {
+			// Seq removes old backups according to its retention settings.
+			// Keep the resource so that doesn't cause a new backup to be
+			// taken on the next apply.
+			detail := "Seq backup " + state.ID.ValueString() + " no longer exists on the server, probably removed by its backup retention settings."
+			if !downloadOK {
+				detail += " The downloaded copy at " + state.DownloadPath.ValueString() + " is missing or changed and cannot be downloaded again;" +
+					" replace this resource (terraform apply -replace) to take a new backup."
+			}
+			resp.Diagnostics.AddWarning("Seq backup no longer exists", detail)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to read Seq backup", err.Error())
+		return
+	}
+
+	if !downloadOK {
+		// Forget the download so the next apply downloads the backup again.
+		resp.Diagnostics.AddWarning(
+			"Downloaded Seq backup is missing or changed",
+			"The file at "+state.DownloadPath.ValueString()+" no longer matches the downloaded backup "+state.ID.ValueString()+
+				"; it will be downloaded again on the next apply.",
+		)
+		state.DownloadPath = types.StringNull()
+		state.SHA256 = types.StringNull()
+	}
+
+	applyBackupResponse(&state, got)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// checkDownloadedBackup reports whether the file at the state's
+// download_path still has the recorded checksum. It is true when nothing was
+// downloaded.
+func checkDownloadedBackup(state BackupModel) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	downloadPath := stringValue(state.DownloadPath)
+	if downloadPath == "" || state.SHA256.IsNull() || state.SHA256.IsUnknown() {
+		return true, diags
+	}
+
+	sum, err := fileSHA256(downloadPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, diags
+	}
+	if err != nil {
+		diags.AddAttributeError(path.Root("download_path"), "Failed to check downloaded Seq backup", err.Error())
+		return false, diags
+	}
+	return sum == state.SHA256.ValueString(), diags
+}
+
+// fileSHA256 returns the hex-encoded SHA-256 checksum of a file.
+func fileSHA256(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (r *BackupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "seq_backup", "update")
+	defer end(&resp.Diagnostics)
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errNotConfigured.Error())
+		return
+	}
+
+	var plan, state BackupModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only download_path can change without replacement.
+	newState := state
+	newState.DownloadPath = plan.DownloadPath
+	if downloadPath := stringValue(plan.DownloadPath); downloadPath == "" {
+		newState.SHA256 = types.StringNull()
+	} else if !plan.DownloadPath.Equal(state.DownloadPath) {
+		sum, err := r.downloadBackup(ctx, state.ID.ValueString(), downloadPath)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to download Seq backup", err.Error())
+			return
+		}
+		newState.SHA256 = types.StringValue(sum)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+// Delete only removes the backup from state; Seq prunes backups according to
+// its retention settings (see seq_backup_settings).
+func (r *BackupResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// downloadBackup downloads a backup to dest and returns its hex-encoded
+// SHA-256 checksum. The file is written to a temporary name and renamed once
+// complete, so dest never holds a partial backup.
+func (r *BackupResource) downloadBackup(ctx context.Context, id, dest string) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), "."+filepath.Base(dest)+".*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	n, err := r.client.DownloadBackup(ctx, id, io.MultiWriter(tmp, hash))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return "", err
+	}
+
+	tflog.Info(ctx, "Downloaded Seq backup", map[string]any{"id": id, "path": dest, "bytes": n})
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func applyBackupResponse(state *BackupModel, resp seqapi.Backup) {
+	state.ID = types.StringValue(resp.ID)
+	state.Filename = types.StringValue(resp.Filename)
+	state.CreatedAt = types.StringValue(resp.CreatedAt)
+	state.SizeBytes = types.Int64Value(resp.SizeBytes)
+}
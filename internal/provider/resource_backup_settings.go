@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	frameworkvalidator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+var _ resource.Resource = (*BackupSettingsResource)(nil)
+var _ resource.ResourceWithConfigure = (*BackupSettingsResource)(nil)
+var _ resource.ResourceWithImportState = (*BackupSettingsResource)(nil)
+
+// backupSettingsID is the id of the singleton seq_backup_settings resource.
+const backupSettingsID = "backup-settings"
+
+var timeOfDayPattern = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d(:[0-5]\d)?$`)
+
+// BackupSettingsResource manages the server's backup schedule and retention
+// via /api/settings.
+//
+// Ref: https://datalust.co/docs/server-http-api#api-settings
+type BackupSettingsResource struct {
+	client *seqapi.Client
+}
+
+type BackupSettingsModel struct {
+	ID            types.String `tfsdk:"id"`
+	Location      types.String `tfsdk:"location"`
+	UTCTimeOfDay  types.String `tfsdk:"utc_time_of_day"`
+	BackupsToKeep types.Int64  `tfsdk:"backups_to_keep"`
+}
+
+func NewBackupSettingsResource() resource.Resource {
+	return &BackupSettingsResource{}
+}
+
+func (r *BackupSettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_backup_settings"
+}
+
+func (r *BackupSettingsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the Seq server's backup schedule and retention. There is one set of backup settings per server; destroying the resource leaves the current settings in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Always \"" + backupSettingsID + "\".",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"location": schema.StringAttribute{
+				Description: "Directory on the Seq server where backups are written. Defaults to the server's current setting.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"utc_time_of_day": schema.StringAttribute{
+				Description: "Time of day (UTC, \"HH:MM\") at which the nightly backup runs. Defaults to the server's current setting.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []frameworkvalidator.String{
+					stringvalidator.RegexMatches(timeOfDayPattern, "must be a time of day in the form HH:MM"),
+				},
+			},
+			"backups_to_keep": schema.Int64Attribute{
+				Description: "Number of backups Seq retains before deleting the oldest. Defaults to the server's current setting.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []frameworkvalidator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *BackupSettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*seqapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *seqapi.Client, got a different type.",
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *BackupSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "seq_backup_settings", "create")
+	defer end(&resp.Diagnostics)
+
+	var plan BackupSettingsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(ctx, plan, &resp.State, &resp.Diagnostics)
+}
+
+func (r *BackupSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "seq_backup_settings", "read")
+	defer end(&resp.Diagnostics)
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errNotConfigured.Error())
+		return
+	}
+
+	var state BackupSettingsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *BackupSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "seq_backup_settings", "update")
+	defer end(&resp.Diagnostics)
+
+	var plan BackupSettingsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(ctx, plan, &resp.State, &resp.Diagnostics)
+}
+
+// Delete only removes the settings from state; Seq always has backup settings.
+func (r *BackupSettingsResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+func (r *BackupSettingsResource) ImportState(ctx context.Context, _ resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, end := traceOperation(ctx, "seq_backup_settings", "import")
+	defer end(&resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), backupSettingsID)...)
+}
+
+// apply writes the configured settings and stores the resulting server values.
+func (r *BackupSettingsResource) apply(ctx context.Context, plan BackupSettingsModel, state *tfsdk.State, diags *diag.Diagnostics) {
+	if r.client == nil {
+		diags.AddError("Provider not configured", errNotConfigured.Error())
+		return
+	}
+
+	if location := stringValue(plan.Location); location != "" {
+		if err := r.client.PutSetting(ctx, seqapi.SettingBackupLocation, location); err != nil {
+			diags.Append(errorDiagnostics("Failed to update Seq backup location", err, map[string]path.Path{"Value": path.Root("location")})...)
+			return
+		}
+	}
+	if timeOfDay := stringValue(plan.UTCTimeOfDay); timeOfDay != "" {
+		if err := r.client.PutSetting(ctx, seqapi.SettingBackupUtcTimeOfDay, timeOfDayWithSeconds(timeOfDay)); err != nil {
+			diags.Append(errorDiagnostics("Failed to update Seq backup time of day", err, map[string]path.Path{"Value": path.Root("utc_time_of_day")})...)
+			return
+		}
+	}
+	if !plan.BackupsToKeep.IsNull() && !plan.BackupsToKeep.IsUnknown() {
+		if err := r.client.PutSetting(ctx, seqapi.SettingBackupsToKeep, plan.BackupsToKeep.ValueInt64()); err != nil {
+			diags.Append(errorDiagnostics("Failed to update Seq backups to keep", err, map[string]path.Path{"Value": path.Root("backups_to_keep")})...)
+			return
+		}
+	}
+
+	diags.Append(r.read(ctx, &plan)...)
+	if diags.HasError() {
+		return
+	}
+	diags.Append(state.Set(ctx, &plan)...)
+}
+
+// read refreshes model from the server settings. A time of day equal to the
+// model's (ignoring seconds) keeps the model's spelling.
+func (r *BackupSettingsResource) read(ctx context.Context, model *BackupSettingsModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	location, err := r.client.GetSetting(ctx, seqapi.SettingBackupLocation)
+	if err != nil {
+		diags.AddError("Failed to read Seq backup location", err.Error())
+		return diags
+	}
+	timeOfDay, err := r.client.GetSetting(ctx, seqapi.SettingBackupUtcTimeOfDay)
+	if err != nil {
+		diags.AddError("Failed to read Seq backup time of day", err.Error())
+		return diags
+	}
+	toKeep, err := r.client.GetSetting(ctx, seqapi.SettingBackupsToKeep)
+	if err != nil {
+		diags.AddError("Failed to read Seq backups to keep", err.Error())
+		return diags
+	}
+
+	model.ID = types.StringValue(backupSettingsID)
+	model.Location = types.StringValue(fmt.Sprint(settingValueOr(location.Value, "")))
+
+	serverTime := fmt.Sprint(settingValueOr(timeOfDay.Value, ""))
+	if current := stringValue(model.UTCTimeOfDay); current == "" || timeOfDayWithSeconds(current) != timeOfDayWithSeconds(serverTime) {
+		model.UTCTimeOfDay = types.StringValue(timeOfDayWithoutSeconds(serverTime))
+	}
+
+	n, err := strconv.ParseInt(fmt.Sprint(settingValueOr(toKeep.Value, "0")), 10, 64)
+	if err != nil {
+		diags.AddError("Unexpected Seq backups to keep value", err.Error())
+		return diags
+	}
+	model.BackupsToKeep = types.Int64Value(n)
+
+	return diags
+}
+
+// settingValueOr returns v, or fallback if v is nil.
+func settingValueOr(v, fallback any) any {
+	if v == nil {
+		return fallback
+	}
+	return v
+}
+
+// timeOfDayWithSeconds converts "HH:MM" to the "HH:MM:SS" form Seq stores.
+func timeOfDayWithSeconds(s string) string {
+	if strings.Count(s, ":") == 1 {
+		return s + ":00"
+	}
+	return s
+}
+
+// timeOfDayWithoutSeconds trims a zero seconds component from "HH:MM:SS".
+func timeOfDayWithoutSeconds(s string) string {
+	if strings.Count(s, ":") == 2 {
+		return strings.TrimSuffix(s, ":00")
+	}
+	return s
+}
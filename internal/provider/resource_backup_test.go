@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+func TestDownloadBackupWritesFileAndChecksum(t *testing.T) {
+	content := []byte("seq backup contents")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/backups/backup-1/download" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(content)
+	}))
+	defer srv.Close()
+
+	r := &BackupResource{client: newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})}
+	dest := filepath.Join(t.TempDir(), "dr", "seq.backup")
+
+	sum, err := r.downloadBackup(context.Background(), "backup-1", dest)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := sha256.Sum256(content)
+	if sum != hex.EncodeToString(want[:]) {
+		t.Fatalf("unexpected checksum %s", sum)
+	}
+	got, err := os.ReadFile(dest)
+	if err != nil || string(got) != string(content) {
+		t.Fatalf("unexpected file contents %q, %v", got, err)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(dest))
+	if len(entries) != 1 {
+		t.Fatalf("expected only the backup file, found %d entries", len(entries))
+	}
+
+	if _, err := r.downloadBackup(context.Background(), "missing", dest+".2"); err == nil {
+		t.Fatalf("expected an error for a missing backup")
+	}
+	if _, err := os.Stat(dest + ".2"); !os.IsNotExist(err) {
+		t.Fatalf("expected no file for a failed download")
+	}
+}
+
+func TestBackupReadChecksDownloadedFile(t *testing.T) {
+	ctx := context.Background()
+	content := []byte("seq backup contents")
+	sum := sha256.Sum256(content)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/backups/backup-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Id": "backup-1", "Filename": "seq.backup", "CreatedAt": "2024-05-01T12:00:00Z", "SizeBytes": 19}`))
+	}))
+	defer srv.Close()
+	r := &BackupResource{client: newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	cases := map[string]struct {
+		id          string
+		file        []byte // nil removes the file
+		wantWarning string
+		wantDetail  string
+		wantCleared bool
+	}{
+		"intact":                 {id: "backup-1", file: content},
+		"changed":                {id: "backup-1", file: []byte("truncated"), wantWarning: "Downloaded Seq backup is missing or changed", wantCleared: true},
+		"deleted":                {id: "backup-1", wantWarning: "Downloaded Seq backup is missing or changed", wantCleared: true},
+		"backup pruned":          {id: "backup-2", file: content, wantWarning: "Seq backup no longer exists"},
+		"backup pruned and lost": {id: "backup-2", wantWarning: "Seq backup no longer exists", wantDetail: "terraform apply -replace"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			dest := filepath.Join(t.TempDir(), "seq.backup")
+			if tc.file != nil {
+				if err := os.WriteFile(dest, tc.file, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			if diags := state.Set(ctx, &BackupModel{
+				ID:           types.StringValue(tc.id),
+				Triggers:     types.MapNull(types.StringType),
+				DownloadPath: types.StringValue(dest),
+				Filename:     types.StringValue("seq.backup"),
+				CreatedAt:    types.StringValue("2024-05-01T12:00:00Z"),
+				SizeBytes:    types.Int64Value(19),
+				SHA256:       types.StringValue(hex.EncodeToString(sum[:])),
+			}); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			resp := &resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			warnings := resp.Diagnostics.Warnings()
+			if tc.wantWarning == "" {
+				if len(warnings) != 0 {
+					t.Fatalf("unexpected warnings: %v", warnings)
+				}
+			} else if len(warnings) != 1 || warnings[0].Summary() != tc.wantWarning || !strings.Contains(warnings[0].Detail(), tc.wantDetail) {
+				t.Fatalf("expected warning %q containing %q, got %v", tc.wantWarning, tc.wantDetail, warnings)
+			}
+
+			var got BackupModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+			if got.ID.ValueString() != tc.id {
+				t.Fatalf("expected the backup to stay in state, got %+v", got)
+			}
+			if cleared := got.DownloadPath.IsNull() && got.SHA256.IsNull(); cleared != tc.wantCleared {
+				t.Fatalf("download attributes cleared = %v, want %v (%+v)", cleared, tc.wantCleared, got)
+			}
+		})
+	}
+}
+
+func TestBackupSettingsReadKeepsTimeOfDaySpelling(t *testing.T) {
+	settings := map[string]any{
+		seqapi.SettingBackupLocation:     "/backups",
+		seqapi.SettingBackupUtcTimeOfDay: "02:30:00",
+		seqapi.SettingBackupsToKeep:      7,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := filepath.Base(r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"Id": name, "Name": name, "Value": settings[name]})
+	}))
+	defer srv.Close()
+
+	r := &BackupSettingsResource{client: newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})}
+
+	model := BackupSettingsModel{UTCTimeOfDay: types.StringValue("02:30")}
+	if diags := r.read(context.Background(), &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if model.UTCTimeOfDay.ValueString() != "02:30" || model.BackupsToKeep.ValueInt64() != 7 || model.Location.ValueString() != "/backups" {
+		t.Fatalf("unexpected model %+v", model)
+	}
+
+	settings[seqapi.SettingBackupUtcTimeOfDay] = "03:00:00"
+	if diags := r.read(context.Background(), &model); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if model.UTCTimeOfDay.ValueString() != "03:00" {
+		t.Fatalf("expected drifted time of day, got %s", model.UTCTimeOfDay)
+	}
+}
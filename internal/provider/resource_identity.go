@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+// ServerIdentityModel is the resource identity of a Seq entity: the server it
+// belongs to and its id on that server.
+type ServerIdentityModel struct {
+	ServerURL types.String `tfsdk:"server_url"`
+	ID        types.String `tfsdk:"id"`
+}
+
+// serverIdentitySchema returns the identity schema for entities described by
+// noun, e.g. "API key".
+func serverIdentitySchema(noun string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"server_url": identityschema.StringAttribute{
+				Description:       "Base URL of the Seq server the " + noun + " belongs to. Defaults to the provider's server_url on import; if set, it must match it.",
+				OptionalForImport: true,
+			},
+			"id": identityschema.StringAttribute{
+				Description:       "Seq " + noun + " id.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// importServerIdentity imports an entity from an import block's identity.
+func importServerIdentity(ctx context.Context, client *seqapi.Client, noun string, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errNotConfigured.Error())
+		return
+	}
+
+	var identity ServerIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(checkServerIdentity(client, noun, identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ID)...)
+}
+
+// importsByIdentity reports whether an import uses an import block's identity
+// rather than an import id.
+func importsByIdentity(req resource.ImportStateRequest) bool {
+	return req.ID == "" && req.Identity != nil && !req.Identity.Raw.IsNull()
+}
+
+// readServerIdentity warns when a stored identity names a server other than
+// the configured one. The server may just have moved, so the read goes ahead
+// and the identity is rewritten to the configured server; the warning flags a
+// state refreshed against the wrong server, which would drop or adopt
+// entities that happen to share an id.
+func readServerIdentity(ctx context.Context, client *seqapi.Client, noun string, identity *tfsdk.ResourceIdentity) diag.Diagnostics {
+	if identity == nil || identity.Raw.IsNull() {
+		return nil
+	}
+	var prior ServerIdentityModel
+	diags := identity.Get(ctx, &prior)
+	if diags.HasError() {
+		return diags
+	}
+	if server := stringValue(prior.ServerURL); server != "" && !sameServerURL(server, client.ServerURL()) {
+		diags.AddWarning(
+			"Seq "+noun+" identity names a different server",
+			"The "+noun+" "+prior.ID.ValueString()+" is identified with server "+server+
+				", but the provider is configured for "+client.ServerURL()+". It is read from, and its identity updated to, "+
+				client.ServerURL()+". If the provider's server_url points at a different Seq instance by mistake, revert it before applying.",
+		)
+	}
+	return diags
+}
+
+func checkServerIdentity(client *seqapi.Client, noun string, identity ServerIdentityModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if server := stringValue(identity.ServerURL); server != "" && !sameServerURL(server, client.ServerURL()) {
+		diags.AddError(
+			"Seq "+noun+" belongs to a different server",
+			"The "+noun+" "+identity.ID.ValueString()+" is identified with server "+server+
+				", but the provider is configured for "+client.ServerURL()+". Check the provider's server_url.",
+		)
+	}
+	return diags
+}
+
+// setServerIdentity stores the identity of the entity with the given id.
+// identity is nil when Terraform does not support resource identity.
+func setServerIdentity(ctx context.Context, client *seqapi.Client, identity *tfsdk.ResourceIdentity, id string) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, ServerIdentityModel{
+		ServerURL: types.StringValue(client.ServerURL()),
+		ID:        types.StringValue(id),
+	})
+}
+
+// sameServerURL reports whether two server URLs name the same server,
+// ignoring the case of the scheme and host, default ports and trailing
+// slashes.
+func sameServerURL(a, b string) bool {
+	return normalizeServerURL(a) == normalizeServerURL(b)
+}
+
+func normalizeServerURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return strings.ToLower(strings.TrimRight(raw, "/"))
+	}
+	scheme := strings.ToLower(u.Scheme)
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		port = ""
+	}
+	if port != "" {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	return scheme + "://" + host + strings.TrimRight(u.EscapedPath(), "/")
+}
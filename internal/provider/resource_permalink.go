@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	frameworkvalidator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+var _ resource.Resource = (*PermalinkResource)(nil)
+var _ resource.ResourceWithConfigure = (*PermalinkResource)(nil)
+var _ resource.ResourceWithImportState = (*PermalinkResource)(nil)
+var _ resource.ResourceWithIdentity = (*PermalinkResource)(nil)
+
+// PermalinkResource pins events via /api/permalinks so they are retained and
+// can be linked to.
+//
+// Ref: https://datalust.co/docs/server-http-api#api-permalinks
+type PermalinkResource struct {
+	client *seqapi.Client
+}
+
+type PermalinkModel struct {
+	ID      types.String `tfsdk:"id"`
+	EventID types.String `tfsdk:"event_id"`
+	OwnerID types.String `tfsdk:"owner_id"`
+	URL     types.String `tfsdk:"url"`
+}
+
+func NewPermalinkResource() resource.Resource {
+	return &PermalinkResource{}
+}
+
+func (r *PermalinkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permalink"
+	// Read rewrites the identity's server_url when the provider's server_url
+	// changes.
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+func (r *PermalinkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Pins a Seq event with a permalink so it is retained and can be linked to.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Seq permalink id.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"event_id": schema.StringAttribute{
+				Description: "Id of the event to pin, e.g. \"event-d3b4e4f1a0c308d9f9b6000000000000\".",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []frameworkvalidator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"owner_id": schema.StringAttribute{
+				Description: "Id of the user that created the permalink.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				Description: "Link to the pinned event in the Seq UI, based on the provider's server_url.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PermalinkResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = serverIdentitySchema("permalink")
+}
+
+func (r *PermalinkResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*seqapi.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *seqapi.Client, got a different type.",
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *PermalinkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, end := traceOperation(ctx, "seq_permalink", "create")
+	defer end(&resp.Diagnostics)
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errNotConfigured.Error())
+		return
+	}
+
+	var plan PermalinkModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreatePermalink(ctx, plan.EventID.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(errorDiagnostics("Failed to create Seq permalink", err, map[string]path.Path{
+			"EventId": path.Root("event_id"),
+		})...)
+		return
+	}
+
+	state := plan
+	applyPermalinkResponse(r.client, &state, created)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setServerIdentity(ctx, r.client, resp.Identity, state.ID.ValueString())...)
+}
+
+func (r *PermalinkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, end := traceOperation(ctx, "seq_permalink", "read")
+	defer end(&resp.Diagnostics)
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errNotConfigured.Error())
+		return
+	}
+
+	var state PermalinkModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ID.IsNull() || state.ID.IsUnknown() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(readServerIdentity(ctx, r.client, "permalink", req.Identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	got, err := r.client.GetPermalink(ctx, state.ID.ValueString())
+	if err != nil {
+		if seqapi.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to read Seq permalink", err.Error())
+		return
+	}
+
+	applyPermalinkResponse(r.client, &state, got)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setServerIdentity(ctx, r.client, resp.Identity, state.ID.ValueString())...)
+}
+
+// Update is never called with changes: event_id requires replacement and the
+// other attributes are computed.
+func (r *PermalinkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, end := traceOperation(ctx, "seq_permalink", "update")
+	defer end(&resp.Diagnostics)
+
+	var plan PermalinkModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *PermalinkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, end := traceOperation(ctx, "seq_permalink", "delete")
+	defer end(&resp.Diagnostics)
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", errNotConfigured.Error())
+		return
+	}
+
+	var state PermalinkModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ID.IsNull() || state.ID.IsUnknown() {
+		return
+	}
+
+	if err := r.client.DeletePermalink(ctx, state.ID.ValueString()); err != nil {
+		if seqapi.IsNotFound(err) {
+			return
+		}
+		resp.Diagnostics.AddError("Failed to delete Seq permalink", err.Error())
+		return
+	}
+}
+
+// ImportState accepts a permalink id, or an identity from an import block;
+// permalinks have no title to import by.
+func (r *PermalinkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, end := traceOperation(ctx, "seq_permalink", "import")
+	defer end(&resp.Diagnostics)
+
+	if importsByIdentity(req) {
+		importServerIdentity(ctx, r.client, "permalink", req, resp)
+		return
+	}
+	importStateByLookup(ctx, r.client, seqapi.ResourcePermalinks, "permalink", nil, req, resp)
+}
+
+func applyPermalinkResponse(client *seqapi.Client, state *PermalinkModel, resp seqapi.Permalink) {
+	state.ID = types.StringValue(resp.ID)
+	state.EventID = types.StringValue(firstNonEmpty(resp.EventID, state.EventID.ValueString()))
+	state.OwnerID = optionalString(resp.OwnerID)
+	state.URL = types.StringValue(client.PermalinkURL(resp.ID))
+}
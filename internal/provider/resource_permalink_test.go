@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+func TestPermalinkURLUsesServerURL(t *testing.T) {
+	cases := map[string]string{
+		"https://seq.example.com":       "https://seq.example.com/#/events?permalink=event-abc",
+		"https://example.com/seq/":      "https://example.com/seq/#/events?permalink=event-abc",
+		"http://localhost:5341/seq/ui/": "http://localhost:5341/seq/ui/#/events?permalink=event-abc",
+	}
+	for serverURL, want := range cases {
+		c := newTestClient(t, seqapi.Config{ServerURL: serverURL})
+		if got := c.PermalinkURL("event-abc"); got != want {
+			t.Fatalf("permalinkURL with %q = %q, want %q", serverURL, got, want)
+		}
+	}
+}
+
+func TestApplyPermalinkResponse(t *testing.T) {
+	r := &PermalinkResource{client: newTestClient(t, seqapi.Config{ServerURL: "https://seq.example.com/"})}
+	owner := "user-admin"
+
+	state := PermalinkModel{EventID: types.StringValue("event-1")}
+	applyPermalinkResponse(r.client, &state, seqapi.Permalink{ID: "permalink-1", OwnerID: &owner})
+
+	if state.ID.ValueString() != "permalink-1" || state.EventID.ValueString() != "event-1" || state.OwnerID.ValueString() != owner {
+		t.Fatalf("unexpected state %+v", state)
+	}
+	if state.URL.ValueString() != "https://seq.example.com/#/events?permalink=permalink-1" {
+		t.Fatalf("unexpected url %s", state.URL)
+	}
+}
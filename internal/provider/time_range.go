@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// timeRange is an optional start/end pair resolved from range, from_date and
+// to_date attributes. Zero times mean "unbounded".
+type timeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// resolveTimeRange reads the range, from_date and to_date attributes shared by
+// query data sources. from_date takes precedence over range; defaultRange
+// applies when neither is set (zero means unbounded).
+func resolveTimeRange(rangeValue, fromValue, toValue types.String, now time.Time, defaultRange time.Duration) (timeRange, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var tr timeRange
+
+	switch {
+	case stringValue(fromValue) != "":
+		from, err := time.Parse(time.RFC3339Nano, fromValue.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("from_date"), "Invalid from_date", err.Error())
+			return tr, diags
+		}
+		tr.From = from.UTC()
+	case stringValue(rangeValue) != "":
+		r, err := parseDuration(rangeValue.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("range"), "Invalid range", err.Error())
+			return tr, diags
+		}
+		tr.From = now.UTC().Add(-r)
+	case defaultRange > 0:
+		tr.From = now.UTC().Add(-defaultRange)
+	}
+
+	if stringValue(toValue) != "" {
+		to, err := time.Parse(time.RFC3339Nano, toValue.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("to_date"), "Invalid to_date", err.Error())
+			return tr, diags
+		}
+		tr.To = to.UTC()
+	}
+
+	return tr, diags
+}
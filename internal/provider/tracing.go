@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the provider's spans.
+const tracerName = "github.com/alexdresko/terraform-provider-seq"
+
+// tracingEnabled reports whether the standard OTEL_* environment variables
+// ask for traces to be exported over OTLP.
+func tracingEnabled() bool {
+	if disabled, _ := strconv.ParseBool(os.Getenv("OTEL_SDK_DISABLED")); disabled {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_EXPORTER"))) {
+	case "otlp":
+		return true
+	case "":
+	default:
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// SetupTracing exports the provider's spans over OTLP when the standard
+// OTEL_* environment variables configure an exporter; otherwise tracing stays
+// disabled and spans cost next to nothing. The exporter itself reads the
+// endpoint, headers and timeout variables. The returned function flushes
+// pending spans and must be called before the process exits.
+func SetupTracing(ctx context.Context, version string) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	if !tracingEnabled() {
+		return noop, nil
+	}
+
+	protocol := firstNonEmpty(os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"), os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"), "http/protobuf")
+	var exporter *otlptrace.Exporter
+	var err error
+	switch protocol {
+	case "http/protobuf":
+		exporter, err = otlptracehttp.New(ctx)
+	case "grpc":
+		exporter, err = otlptracegrpc.New(ctx)
+	default:
+		return noop, fmt.Errorf("unsupported OTLP protocol %q; use grpc or http/protobuf", protocol)
+	}
+	if err != nil {
+		return noop, fmt.Errorf("create OTLP trace exporter: %w", err)
+	}
+
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the defaults.
+	res, err := sdkresource.New(ctx,
+		sdkresource.WithAttributes(
+			attribute.String("service.name", "terraform-provider-seq"),
+			attribute.String("service.version", version),
+		),
+		sdkresource.WithFromEnv(),
+		sdkresource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return noop, fmt.Errorf("build OpenTelemetry resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	parent := trace.SpanContextFromContext(propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{
+		"traceparent": os.Getenv("TRACEPARENT"),
+		"tracestate":  os.Getenv("TRACESTATE"),
+	}))
+	otel.SetTracerProvider(parentedTracerProvider{TracerProvider: tp, parent: parent})
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return tp.Shutdown, nil
+}
+
+// parentedTracerProvider parents root spans to the span the caller passed in
+// the TRACEPARENT environment variable, e.g. a CI job span, since Terraform
+// does not propagate trace context to providers. Wrapping the global provider
+// covers the spans seqapi starts, too.
+type parentedTracerProvider struct {
+	trace.TracerProvider
+	parent trace.SpanContext
+}
+
+func (p parentedTracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return parentedTracer{Tracer: p.TracerProvider.Tracer(name, opts...), parent: p.parent}
+}
+
+type parentedTracer struct {
+	trace.Tracer
+	parent trace.SpanContext
+}
+
+func (t parentedTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() && t.parent.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, t.parent)
+	}
+	return t.Tracer.Start(ctx, name, opts...)
+}
+
+// startSpan starts a span from the global tracer provider, which does nothing
+// unless SetupTracing installed an exporter.
+func startSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// traceOperation starts a span for a resource operation such as
+// "seq_api_key create". The returned function ends it, recording any error
+// diagnostics; call it as `defer end(&resp.Diagnostics)`.
+func traceOperation(ctx context.Context, typeName, operation string) (context.Context, func(*diag.Diagnostics)) {
+	ctx, span := startSpan(ctx, typeName+" "+operation, trace.WithAttributes(
+		attribute.String("terraform.resource.type", typeName),
+		attribute.String("terraform.operation", operation),
+	))
+	return ctx, func(diags *diag.Diagnostics) {
+		for _, d := range diags.Errors() {
+			span.SetStatus(codes.Error, d.Summary())
+			span.AddEvent("error", trace.WithAttributes(
+				attribute.String("summary", d.Summary()),
+				attribute.String("detail", d.Detail()),
+			))
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/alexdresko/terraform-provider-seq/seqapi"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestResourceOperationSpans(t *testing.T) {
+	recorder := recordSpans(t)
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"Error":"boom"}`))
+	}))
+	defer srv.Close()
+	r := &APIKeyResource{client: newTestClient(t, seqapi.Config{ServerURL: srv.URL, HTTPClient: srv.Client()})}
+
+	state := newAPIKeyState(t, "apikey-12")
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected the delete to fail")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	httpSpan, opSpan := spans[0], spans[1]
+
+	if opSpan.Name() != "seq_api_key delete" || opSpan.Status().Code != codes.Error {
+		t.Fatalf("operation span = %q (%v)", opSpan.Name(), opSpan.Status())
+	}
+	if got := spanAttribute(opSpan, "terraform.resource.type").AsString(); got != "seq_api_key" {
+		t.Fatalf("resource type = %q", got)
+	}
+
+	if httpSpan.Name() != "DELETE /api/apikeys/{id}" || httpSpan.Parent().SpanID() != opSpan.SpanContext().SpanID() {
+		t.Fatalf("HTTP span = %q, parent %v", httpSpan.Name(), httpSpan.Parent())
+	}
+	if got := spanAttribute(httpSpan, "http.response.status_code").AsInt64(); got != http.StatusInternalServerError {
+		t.Fatalf("status code = %d", got)
+	}
+	if httpSpan.Status().Code != codes.Error || httpSpan.Status().Description != "boom" {
+		t.Fatalf("HTTP span status = %v", httpSpan.Status())
+	}
+	// No propagator is installed outside SetupTracing.
+	if traceparent != "" {
+		t.Fatalf("unexpected traceparent header %q", traceparent)
+	}
+}
+
+func TestTracingEnabled(t *testing.T) {
+	cases := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"unset", nil, false},
+		{"endpoint", map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318"}, true},
+		{"traces endpoint", map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://collector:4318/v1/traces"}, true},
+		{"exporter", map[string]string{"OTEL_TRACES_EXPORTER": "otlp"}, true},
+		{"exporter none", map[string]string{"OTEL_TRACES_EXPORTER": "none", "OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318"}, false},
+		{"sdk disabled", map[string]string{"OTEL_SDK_DISABLED": "true", "OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318"}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, key := range []string{"OTEL_SDK_DISABLED", "OTEL_TRACES_EXPORTER", "OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"} {
+				t.Setenv(key, tc.env[key])
+			}
+			if got := tracingEnabled(); got != tc.want {
+				t.Fatalf("tracingEnabled() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParentedTracerProvider(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	tp := parentedTracerProvider{TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), parent: parent}
+
+	// Root spans from any tracer, such as seqapi's, join the parent trace;
+	// child spans keep their own parent.
+	ctx, root := tp.Tracer("github.com/alexdresko/terraform-provider-seq/seqapi").Start(context.Background(), "GET /api")
+	_, child := tp.Tracer(tracerName).Start(ctx, "child")
+	child.End()
+	root.End()
+
+	spans := recorder.Ended()
+	if got := spans[1].Parent(); got.TraceID() != parent.TraceID() || got.SpanID() != parent.SpanID() {
+		t.Fatalf("root span parent = %v, want %v", got, parent)
+	}
+	if got := spans[0].Parent().SpanID(); got != spans[1].SpanContext().SpanID() {
+		t.Fatalf("child span parent = %v, want the root span", got)
+	}
+}
@@ -0,0 +1,76 @@
+package seqfake
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+// apiKeyCollection mimics /api/apikeys. Permissions are read from, and
+// returned in, the field the configured Seq version uses, and the token is
+// only returned when a key is created.
+var apiKeyCollection = Collection{
+	ResourceGroup: "ApiKeys",
+	IDPrefix:      "apikey",
+	Validate: func(_ *Server, body Entity) map[string][]string {
+		if title, _ := body["Title"].(string); strings.TrimSpace(title) == "" {
+			return map[string][]string{"Title": {"The Title field is required."}}
+		}
+		return nil
+	},
+	Store: func(s *Server, prior, body Entity) Entity {
+		stored := Entity{
+			"Title":               body["Title"],
+			"OwnerId":             body["OwnerId"],
+			"AssignedPermissions": body[s.PermissionsField()],
+			"InputSettings":       body["InputSettings"],
+		}
+		if prior == nil {
+			stored["Token"] = newToken()
+		} else {
+			stored["Token"] = prior["Token"]
+		}
+		if owner, _ := stored["OwnerId"].(string); owner == "" {
+			stored["OwnerId"] = DefaultOwnerID
+			if prior != nil && prior["OwnerId"] != nil {
+				stored["OwnerId"] = prior["OwnerId"]
+			}
+		}
+		if stored["AssignedPermissions"] == nil {
+			stored["AssignedPermissions"] = []any{}
+		}
+		return stored
+	},
+	Render: func(s *Server, stored Entity, created bool) Entity {
+		perms := stored["AssignedPermissions"]
+		if perms == nil {
+			perms = stored["Permissions"]
+		}
+		if perms == nil {
+			perms = []any{}
+		}
+		delete(stored, "AssignedPermissions")
+		delete(stored, "Permissions")
+		stored[s.PermissionsField()] = perms
+
+		token, _ := stored["Token"].(string)
+		delete(stored, "Token")
+		if created {
+			stored["Token"] = token
+		}
+		if len(token) >= 4 {
+			stored["TokenPrefix"] = token[:4]
+		}
+
+		if stored["InputSettings"] == nil {
+			stored["InputSettings"] = Entity{"AppliedProperties": []any{}, "Filter": nil, "MinimumLevel": nil}
+		}
+		return stored
+	},
+}
+
+func newToken() string {
+	b := make([]byte, 10)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,393 @@
+// Package seqfake provides an in-memory fake of the Seq HTTP API for tests.
+//
+// The fake serves the API root, /health, /api/expressions/to-strict and a set
+// of entity collections (API keys by default) from memory. Tests can seed,
+// change and remove entities directly to simulate changes made outside
+// Terraform, and register further collections with AddCollection.
+package seqfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Entity is a Seq entity as it appears on the wire, e.g. an API key document.
+type Entity = map[string]any
+
+// DefaultVersion is the Seq version the fake reports unless WithVersion is
+// used.
+const DefaultVersion = "2024.3.13545"
+
+// DefaultOwnerID is the owner assigned to API keys created without one.
+const DefaultOwnerID = "user-admin"
+
+// Collection describes the entities served under /api/<name>.
+type Collection struct {
+	// ResourceGroup names the collection in the Links of the API root
+	// document, e.g. "ApiKeys" for "ApiKeysResources". When empty, the
+	// capitalized collection name is used.
+	ResourceGroup string
+	// IDPrefix is used for generated ids, e.g. "apikey" gives "apikey-1".
+	IDPrefix string
+	// Validate reports problems with a create or update body, keyed by
+	// property name. Any problems are returned as a 400 validation response.
+	Validate func(s *Server, body Entity) map[string][]string
+	// Store builds the stored entity from a create or update body. prior is
+	// nil on create. When nil, the body is stored as is.
+	Store func(s *Server, prior, body Entity) Entity
+	// Render shapes a stored entity for a response. created is set for the
+	// response to a create. When nil, the stored entity is returned.
+	Render func(s *Server, stored Entity, created bool) Entity
+}
+
+// Server is an in-memory Seq API. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	apiKey  string
+	version string
+
+	mu          sync.Mutex
+	collections map[string]*collection
+	strict      map[string]string
+	requests    []string
+}
+
+type collection struct {
+	def      Collection
+	nextID   int
+	entities map[string]Entity
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithAPIKey makes the fake reject requests that do not send key in the
+// X-Seq-ApiKey header.
+func WithAPIKey(key string) Option {
+	return func(s *Server) { s.apiKey = key }
+}
+
+// WithVersion sets the version reported by the API root. Versions before
+// 2021.1 use the legacy Permissions field on API keys instead of
+// AssignedPermissions.
+func WithVersion(version string) Option {
+	return func(s *Server) { s.version = version }
+}
+
+// New starts a fake Seq server that is closed when the test finishes.
+func New(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+
+	s := &Server{
+		version:     DefaultVersion,
+		collections: map[string]*collection{},
+		strict:      map[string]string{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.AddCollection("apikeys", apiKeyCollection)
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// AddCollection serves def under /api/<name>, replacing any collection
+// already registered with that name.
+func (s *Server) AddCollection(name string, def Collection) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collections[name] = &collection{def: def, entities: map[string]Entity{}}
+}
+
+// LegacyPermissions reports whether API keys use the Permissions field
+// rather than AssignedPermissions, based on the configured version.
+func (s *Server) LegacyPermissions() bool {
+	major, err := strconv.Atoi(strings.SplitN(s.version, ".", 2)[0])
+	return err == nil && major < 2021
+}
+
+// PermissionsField returns the API key field that carries permissions.
+func (s *Server) PermissionsField() string {
+	if s.LegacyPermissions() {
+		return "Permissions"
+	}
+	return "AssignedPermissions"
+}
+
+// SetStrictExpression makes /api/expressions/to-strict convert fuzzy to
+// strict. Other expressions are returned unchanged.
+func (s *Server) SetStrictExpression(fuzzy, strict string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.strict[fuzzy] = strict
+}
+
+// Put stores entity in the named collection as if it had been changed
+// outside Terraform, assigning an id when it has none, and returns the id.
+func (s *Server) Put(name string, entity Entity) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.mustCollection(name)
+	id, _ := entity["Id"].(string)
+	if id == "" {
+		id = c.newID()
+	}
+	stored := clone(entity)
+	stored["Id"] = id
+	c.entities[id] = stored
+	return id
+}
+
+// Get returns a copy of the stored entity.
+func (s *Server) Get(name, id string) (Entity, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entity, ok := s.mustCollection(name).entities[id]
+	if !ok {
+		return nil, false
+	}
+	return clone(entity), true
+}
+
+// Update applies fn to the stored entity in place. It reports whether the
+// entity exists.
+func (s *Server) Update(name, id string, fn func(Entity)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entity, ok := s.mustCollection(name).entities[id]
+	if ok {
+		fn(entity)
+		entity["Id"] = id
+	}
+	return ok
+}
+
+// Delete removes an entity. It reports whether the entity existed.
+func (s *Server) Delete(name, id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.mustCollection(name)
+	_, ok := c.entities[id]
+	delete(c.entities, id)
+	return ok
+}
+
+// List returns copies of the stored entities in id order.
+func (s *Server) List(name string) []Entity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.mustCollection(name)
+	out := make([]Entity, 0, len(c.entities))
+	for _, id := range c.ids() {
+		out = append(out, clone(c.entities[id]))
+	}
+	return out
+}
+
+// Requests returns the "METHOD /path" of every request served so far.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) mustCollection(name string) *collection {
+	c, ok := s.collections[name]
+	if !ok {
+		panic(fmt.Sprintf("seqfake: no collection %q", name))
+	}
+	return c
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+
+	if s.apiKey != "" && r.Header.Get("X-Seq-ApiKey") != s.apiKey {
+		writeError(w, http.StatusUnauthorized, "The API key is missing or invalid.")
+		return
+	}
+
+	switch p := strings.TrimSuffix(r.URL.Path, "/"); {
+	case p == "/health" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, Entity{"status": "The Seq node is in service."})
+	case p == "/api" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, Entity{"Product": "Seq", "Version": s.version, "Links": s.rootLinks()})
+	case p == "/api/expressions/to-strict" && r.Method == http.MethodGet:
+		s.toStrict(w, r)
+	case strings.HasPrefix(p, "/api/"):
+		s.serveCollection(w, r, strings.Split(strings.TrimPrefix(p, "/api/"), "/"))
+	default:
+		writeError(w, http.StatusNotFound, "Not found.")
+	}
+}
+
+// rootLinks lists the resource groups of the registered collections, like
+// Seq's API root document, so clients can discover which are supported.
+func (s *Server) rootLinks() map[string]string {
+	links := map[string]string{"ExpressionsResources": "api/expressions/resources"}
+	for name, c := range s.collections {
+		group := c.def.ResourceGroup
+		if group == "" {
+			group = strings.ToUpper(name[:1]) + name[1:]
+		}
+		links[group+"Resources"] = "api/" + name + "/resources"
+	}
+	return links
+}
+
+func (s *Server) toStrict(w http.ResponseWriter, r *http.Request) {
+	fuzzy := r.URL.Query().Get("fuzzy")
+	strict, ok := s.strict[fuzzy]
+	if !ok {
+		strict = fuzzy
+	}
+	writeJSON(w, http.StatusOK, Entity{"StrictExpression": strict, "MatchedAsText": false})
+}
+
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, segments []string) {
+	c, ok := s.collections[segments[0]]
+	if !ok || len(segments) > 2 {
+		writeError(w, http.StatusNotFound, "Not found.")
+		return
+	}
+
+	if len(segments) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			out := make([]Entity, 0, len(c.entities))
+			for _, id := range c.ids() {
+				out = append(out, c.render(s, c.entities[id], false))
+			}
+			writeJSON(w, http.StatusOK, out)
+		case http.MethodPost:
+			body, ok := s.readBody(w, r, c)
+			if !ok {
+				return
+			}
+			stored := c.store(s, nil, body)
+			stored["Id"] = c.newID()
+			c.entities[stored["Id"].(string)] = stored
+			writeJSON(w, http.StatusCreated, c.render(s, stored, true))
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method not allowed.")
+		}
+		return
+	}
+
+	id := segments[1]
+	prior, ok := c.entities[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("The entity with id %s could not be found.", id))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, c.render(s, prior, false))
+	case http.MethodPut:
+		body, ok := s.readBody(w, r, c)
+		if !ok {
+			return
+		}
+		if bodyID, _ := body["Id"].(string); bodyID != id {
+			writeError(w, http.StatusBadRequest, "The entity id in the body does not match the URL.")
+			return
+		}
+		stored := c.store(s, prior, body)
+		stored["Id"] = id
+		c.entities[id] = stored
+		writeJSON(w, http.StatusOK, c.render(s, stored, false))
+	case http.MethodDelete:
+		delete(c.entities, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed.")
+	}
+}
+
+func (s *Server) readBody(w http.ResponseWriter, r *http.Request, c *collection) (Entity, bool) {
+	var body Entity
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body == nil {
+		writeError(w, http.StatusBadRequest, "The request body is not a JSON object.")
+		return nil, false
+	}
+	if c.def.Validate != nil {
+		if problems := c.def.Validate(s, body); len(problems) > 0 {
+			writeJSON(w, http.StatusBadRequest, Entity{
+				"title":  "One or more validation errors occurred.",
+				"status": http.StatusBadRequest,
+				"errors": problems,
+			})
+			return nil, false
+		}
+	}
+	return body, true
+}
+
+func (c *collection) newID() string {
+	for {
+		c.nextID++
+		id := fmt.Sprintf("%s-%d", c.def.IDPrefix, c.nextID)
+		if _, taken := c.entities[id]; !taken {
+			return id
+		}
+	}
+}
+
+func (c *collection) ids() []string {
+	ids := make([]string, 0, len(c.entities))
+	for id := range c.entities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (c *collection) store(s *Server, prior, body Entity) Entity {
+	if c.def.Store == nil {
+		return clone(body)
+	}
+	return c.def.Store(s, prior, body)
+}
+
+func (c *collection) render(s *Server, stored Entity, created bool) Entity {
+	if c.def.Render == nil {
+		return clone(stored)
+	}
+	return c.def.Render(s, clone(stored), created)
+}
+
+// clone deep-copies an entity through JSON, so callers never share maps or
+// slices with the store.
+func clone(entity Entity) Entity {
+	b, err := json.Marshal(entity)
+	if err != nil {
+		panic(fmt.Sprintf("seqfake: entity is not JSON: %v", err))
+	}
+	var out Entity
+	if err := json.Unmarshal(b, &out); err != nil {
+		panic(fmt.Sprintf("seqfake: entity is not JSON: %v", err))
+	}
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, Entity{"Error": message})
+}
@@ -0,0 +1,112 @@
+package seqfake
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func request(t *testing.T, s *Server, method, path string, body any, out any) int {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, s.URL+path, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Seq-ApiKey", "key")
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestAPIKeyPermissionShapes(t *testing.T) {
+	for _, tc := range []struct {
+		version string
+		field   string
+	}{
+		{DefaultVersion, "AssignedPermissions"},
+		{"2020.5.4778", "Permissions"},
+	} {
+		t.Run(tc.field, func(t *testing.T) {
+			s := New(t, WithVersion(tc.version))
+
+			var created Entity
+			status := request(t, s, http.MethodPost, "/api/apikeys", Entity{"Title": "k", tc.field: []string{"Read"}}, &created)
+			if status != http.StatusCreated {
+				t.Fatalf("create status = %d", status)
+			}
+			if created["Token"] == nil || created["OwnerId"] != DefaultOwnerID {
+				t.Fatalf("created = %v, want a token and the default owner", created)
+			}
+
+			var got Entity
+			request(t, s, http.MethodGet, "/api/apikeys/"+created["Id"].(string), nil, &got)
+			if !reflect.DeepEqual(got[tc.field], []any{"Read"}) {
+				t.Fatalf("%s = %v, want [Read]", tc.field, got[tc.field])
+			}
+			if _, ok := got["Token"]; ok {
+				t.Fatalf("token returned after create: %v", got)
+			}
+		})
+	}
+}
+
+func TestAPIKeyValidation(t *testing.T) {
+	s := New(t)
+
+	var problem struct {
+		Errors map[string][]string `json:"errors"`
+	}
+	if status := request(t, s, http.MethodPost, "/api/apikeys", Entity{"Title": ""}, &problem); status != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", status)
+	}
+	if len(problem.Errors["Title"]) != 1 {
+		t.Fatalf("errors = %v, want a Title error", problem.Errors)
+	}
+
+	id := s.Put("apikeys", Entity{"Title": "k"})
+	if status := request(t, s, http.MethodPut, "/api/apikeys/"+id, Entity{"Id": "other", "Title": "k"}, nil); status != http.StatusBadRequest {
+		t.Fatalf("mismatched id status = %d, want 400", status)
+	}
+}
+
+func TestAPIKeyRequired(t *testing.T) {
+	s := New(t, WithAPIKey("secret"))
+	if status := request(t, s, http.MethodGet, "/health", nil, nil); status != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", status)
+	}
+}
+
+func TestCustomCollection(t *testing.T) {
+	s := New(t)
+	s.AddCollection("permalinks", Collection{IDPrefix: "permalink"})
+
+	var created Entity
+	request(t, s, http.MethodPost, "/api/permalinks", Entity{"EventId": "event-1"}, &created)
+	if created["Id"] != "permalink-1" {
+		t.Fatalf("created = %v", created)
+	}
+
+	s.Delete("permalinks", "permalink-1")
+	if status := request(t, s, http.MethodGet, "/api/permalinks/permalink-1", nil, nil); status != http.StatusNotFound {
+		t.Fatalf("status after delete = %d, want 404", status)
+	}
+	if got := s.Requests(); len(got) != 2 || got[0] != "POST /api/permalinks" {
+		t.Fatalf("requests = %v", got)
+	}
+}
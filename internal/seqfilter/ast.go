@@ -0,0 +1,234 @@
+package seqfilter
+
+import "strings"
+
+// Expr is a node in a parsed filter expression. String renders the node in
+// canonical form: lower-case keywords, strict operator spellings, single
+// spaces around binary operators and only the parentheses that precedence
+// requires.
+type Expr interface {
+	String() string
+	// Pos is the byte offset of the node in the source.
+	Pos() int
+	precedence() int
+}
+
+// Operator precedence, lowest to highest.
+const (
+	precOr = iota + 1
+	precAnd
+	precNot
+	precComparison
+	precAdditive
+	precMultiplicative
+	precUnary
+	precPrimary
+)
+
+// Property references an event property such as Application or @Level.
+type Property struct {
+	Name   string
+	Offset int
+}
+
+func (e *Property) String() string  { return e.Name }
+func (e *Property) Pos() int        { return e.Offset }
+func (e *Property) precedence() int { return precPrimary }
+
+// LiteralKind identifies the type of a Literal.
+type LiteralKind int
+
+const (
+	LiteralString LiteralKind = iota
+	LiteralNumber
+	LiteralDuration
+	LiteralRegex
+	LiteralBool
+	LiteralNull
+)
+
+// Literal is a constant value. For strings Value is unescaped; for other
+// kinds it holds the source text, with keywords lower-cased.
+type Literal struct {
+	Kind   LiteralKind
+	Value  string
+	Offset int
+}
+
+func (e *Literal) String() string {
+	if e.Kind == LiteralString {
+		return QuoteString(e.Value)
+	}
+	return e.Value
+}
+
+func (e *Literal) Pos() int        { return e.Offset }
+func (e *Literal) precedence() int { return precPrimary }
+
+// Member is a dotted property access such as RequestPath.Segments.
+type Member struct {
+	Target Expr
+	Name   string
+}
+
+func (e *Member) String() string  { return wrap(e.Target, precPrimary) + "." + e.Name }
+func (e *Member) Pos() int        { return e.Target.Pos() }
+func (e *Member) precedence() int { return precPrimary }
+
+// Index is an indexer such as Items[0], @Properties['a b'] or Tags[?].
+// Wildcard is "?" or "*" when the indexer is a wildcard, in which case
+// Index is nil.
+type Index struct {
+	Target   Expr
+	Index    Expr
+	Wildcard string
+}
+
+func (e *Index) String() string {
+	inner := e.Wildcard
+	if e.Index != nil {
+		inner = e.Index.String()
+	}
+	return wrap(e.Target, precPrimary) + "[" + inner + "]"
+}
+
+func (e *Index) Pos() int        { return e.Target.Pos() }
+func (e *Index) precedence() int { return precPrimary }
+
+// Call is a function call such as StartsWith(@Message, 'x').
+type Call struct {
+	Name   string
+	Args   []Expr
+	Offset int
+}
+
+func (e *Call) String() string  { return e.Name + "(" + joinExprs(e.Args) + ")" }
+func (e *Call) Pos() int        { return e.Offset }
+func (e *Call) precedence() int { return precPrimary }
+
+// Array is an array literal such as ['a', 'b'].
+type Array struct {
+	Elems  []Expr
+	Offset int
+}
+
+func (e *Array) String() string  { return "[" + joinExprs(e.Elems) + "]" }
+func (e *Array) Pos() int        { return e.Offset }
+func (e *Array) precedence() int { return precPrimary }
+
+// Unary is a prefix operation: "not" or "-".
+type Unary struct {
+	Op     string
+	X      Expr
+	Offset int
+}
+
+func (e *Unary) String() string {
+	if e.Op == "not" {
+		return "not " + wrap(e.X, precNot)
+	}
+	return e.Op + wrap(e.X, precPrimary)
+}
+
+func (e *Unary) Pos() int { return e.Offset }
+
+func (e *Unary) precedence() int {
+	if e.Op == "not" {
+		return precNot
+	}
+	return precUnary
+}
+
+// Binary is an infix operation. Op is one of or, and, =, <>, <, <=, >, >=,
+// like, not like, in, not in, +, -, *, / or %. CI marks a case-insensitive
+// comparison (the ci modifier).
+type Binary struct {
+	Op string
+	L  Expr
+	R  Expr
+	CI bool
+}
+
+func (e *Binary) String() string {
+	p := e.precedence()
+	s := wrap(e.L, p) + " " + e.Op + " " + wrap(e.R, p+1)
+	if e.CI {
+		s += " ci"
+	}
+	return s
+}
+
+func (e *Binary) Pos() int { return e.L.Pos() }
+
+func (e *Binary) precedence() int {
+	switch e.Op {
+	case "or":
+		return precOr
+	case "and":
+		return precAnd
+	case "+", "-":
+		return precAdditive
+	case "*", "/", "%":
+		return precMultiplicative
+	default:
+		return precComparison
+	}
+}
+
+// IsNull is a null test: "X is null" or "X is not null".
+type IsNull struct {
+	X   Expr
+	Not bool
+}
+
+func (e *IsNull) String() string {
+	if e.Not {
+		return wrap(e.X, precAdditive) + " is not null"
+	}
+	return wrap(e.X, precAdditive) + " is null"
+}
+
+func (e *IsNull) Pos() int        { return e.X.Pos() }
+func (e *IsNull) precedence() int { return precComparison }
+
+// EscapeString doubles embedded single quotes so s can be placed between
+// quotes in a Seq string literal.
+func EscapeString(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
+
+// QuoteString renders s as a Seq string literal, doubling embedded quotes.
+func QuoteString(s string) string {
+	return "'" + EscapeString(s) + "'"
+}
+
+// PropertyAccessor renders a reference to the named event property. Names
+// that are not plain identifiers (or are reserved words) are accessed through
+// @Properties, e.g. @Properties['user id'].
+func PropertyAccessor(name string) string {
+	ident := strings.TrimPrefix(name, "@")
+	valid := ident != "" && isIdentStart(ident[0]) && !isKeyword(ident, reservedWords...) && !isKeyword(ident, "true", "false", "null")
+	for i := 0; valid && i < len(ident); i++ {
+		valid = isIdentPart(ident[i])
+	}
+	if valid {
+		return name
+	}
+	return "@Properties[" + QuoteString(name) + "]"
+}
+
+// wrap renders e, parenthesized if it binds more loosely than prec.
+func wrap(e Expr, prec int) string {
+	if e.precedence() < prec {
+		return "(" + e.String() + ")"
+	}
+	return e.String()
+}
+
+func joinExprs(es []Expr) string {
+	parts := make([]string, len(es))
+	for i, e := range es {
+		parts[i] = e.String()
+	}
+	return strings.Join(parts, ", ")
+}
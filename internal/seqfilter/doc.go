@@ -0,0 +1,10 @@
+// Package seqfilter lexes and parses Seq filter expressions.
+//
+// It understands the strict Seq query language used by API key, signal and
+// alert filters: properties (including @-prefixed built-ins), comparison and
+// logical operators, like/in/is null, function calls, and string, number,
+// duration and regular expression literals. Parsed expressions render to a
+// canonical string so that equivalent filters can be compared offline.
+//
+// Ref: https://datalust.co/docs/query-syntax
+package seqfilter
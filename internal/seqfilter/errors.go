@@ -0,0 +1,34 @@
+package seqfilter
+
+import "fmt"
+
+// Error describes a lexing or parsing failure at a position in the source.
+type Error struct {
+	// Offset is the zero-based byte offset of the failure.
+	Offset int
+	// Line and Column are one-based, with Column counted in characters.
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Msg)
+}
+
+func errorAt(src string, offset int, msg string) *Error {
+	if offset > len(src) {
+		offset = len(src)
+	}
+	// Ranging over the string counts characters rather than bytes.
+	line, col := 1, 1
+	for _, r := range src[:offset] {
+		if r == '\n' {
+			line++
+			col = 1
+			continue
+		}
+		col++
+	}
+	return &Error{Offset: offset, Line: line, Column: col, Msg: msg}
+}
@@ -0,0 +1,293 @@
+package seqfilter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind identifies the lexical class of a token.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokBuiltin
+	tokString
+	tokNumber
+	tokDuration
+	tokRegex
+	tokOperator
+	tokPunct
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokEOF:
+		return "end of input"
+	case tokIdent:
+		return "identifier"
+	case tokBuiltin:
+		return "built-in property"
+	case tokString:
+		return "string"
+	case tokNumber:
+		return "number"
+	case tokDuration:
+		return "duration"
+	case tokRegex:
+		return "regular expression"
+	case tokOperator:
+		return "operator"
+	case tokPunct:
+		return "punctuation"
+	default:
+		return "token"
+	}
+}
+
+// token is a single lexeme. Text holds the raw source text, except for
+// strings where it holds the unescaped value.
+type token struct {
+	Kind tokenKind
+	Text string
+	Pos  int
+}
+
+// durationSuffixes are the units accepted after a number to form a duration
+// literal such as 30s or 1d. Longer suffixes come first so "ms" wins over "m".
+var durationSuffixes = []string{"ms", "us", "d", "h", "m", "s"}
+
+// operators are matched longest-first.
+var operators = []string{"<>", "<=", ">=", "==", "!=", "&&", "||", "=", "<", ">", "+", "-", "*", "/", "%", "!"}
+
+type lexer struct {
+	src    string
+	pos    int
+	tokens []token
+}
+
+// lex splits src into tokens, ending with a tokEOF token.
+func lex(src string) ([]token, error) {
+	l := &lexer{src: src}
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		l.tokens = append(l.tokens, tok)
+		if tok.Kind == tokEOF {
+			return l.tokens, nil
+		}
+	}
+}
+
+// operandEnded reports whether the previous token can end an operand, which
+// decides whether a following '/' is division or the start of a regex.
+func (l *lexer) operandEnded() bool {
+	if len(l.tokens) == 0 {
+		return false
+	}
+	prev := l.tokens[len(l.tokens)-1]
+	switch prev.Kind {
+	case tokIdent:
+		return !isKeyword(prev.Text, "and", "or", "not", "like", "in", "is")
+	case tokBuiltin, tokString, tokNumber, tokDuration, tokRegex:
+		return true
+	case tokPunct:
+		return prev.Text == ")" || prev.Text == "]" || prev.Text == "}"
+	default:
+		return false
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) {
+		r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		l.pos += size
+	}
+	if l.pos >= len(l.src) {
+		return token{Kind: tokEOF, Pos: l.pos}, nil
+	}
+
+	start := l.pos
+	c := l.src[l.pos]
+	switch {
+	case c == '\'':
+		return l.lexString()
+	case c == '@':
+		l.pos++
+		name := l.scanIdent()
+		if name == "" {
+			return token{}, errorAt(l.src, start, "expected a property name after '@'")
+		}
+		return token{Kind: tokBuiltin, Text: "@" + name, Pos: start}, nil
+	case isIdentStart(c):
+		return token{Kind: tokIdent, Text: l.scanIdent(), Pos: start}, nil
+	case c >= '0' && c <= '9':
+		return l.lexNumber()
+	case c == '/' && !l.operandEnded():
+		return l.lexRegex()
+	case strings.ContainsRune("()[]{},.:?", rune(c)):
+		l.pos++
+		return token{Kind: tokPunct, Text: string(c), Pos: start}, nil
+	}
+
+	// Seq filters have no lambdas; reject '=>' here rather than lexing it as
+	// '=' followed by a stray '>'.
+	if strings.HasPrefix(l.src[l.pos:], "=>") {
+		return token{}, errorAt(l.src, start, "'=>' is not supported in Seq filter expressions")
+	}
+
+	for _, op := range operators {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			l.pos += len(op)
+			return token{Kind: tokOperator, Text: op, Pos: start}, nil
+		}
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+	if r == '"' {
+		return token{}, errorAt(l.src, start, "strings must be delimited with single quotes")
+	}
+	return token{}, errorAt(l.src, start, fmt.Sprintf("unexpected character %q", r))
+}
+
+func (l *lexer) scanIdent() string {
+	start := l.pos
+	for l.pos < len(l.src) && isIdentPart(l.src[l.pos]) {
+		l.pos++
+	}
+	return l.src[start:l.pos]
+}
+
+func (l *lexer) lexString() (token, error) {
+	start := l.pos
+	l.pos++ // opening quote
+
+	var sb strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if c == '\'' {
+			// A doubled quote is an escaped quote.
+			if l.pos+1 < len(l.src) && l.src[l.pos+1] == '\'' {
+				sb.WriteByte('\'')
+				l.pos += 2
+				continue
+			}
+			l.pos++
+			return token{Kind: tokString, Text: sb.String(), Pos: start}, nil
+		}
+		sb.WriteByte(c)
+		l.pos++
+	}
+	return token{}, errorAt(l.src, start, "unterminated string literal")
+}
+
+func (l *lexer) lexNumber() (token, error) {
+	start := l.pos
+
+	if strings.HasPrefix(l.src[l.pos:], "0x") || strings.HasPrefix(l.src[l.pos:], "0X") {
+		l.pos += 2
+		digits := l.pos
+		for l.pos < len(l.src) && isHexDigit(l.src[l.pos]) {
+			l.pos++
+		}
+		if l.pos == digits {
+			return token{}, errorAt(l.src, start, "expected hexadecimal digits after 0x")
+		}
+		return l.finishNumber(start)
+	}
+
+	l.scanDigits()
+	if l.pos+1 < len(l.src) && l.src[l.pos] == '.' && isDigit(l.src[l.pos+1]) {
+		l.pos++
+		l.scanDigits()
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		save := l.pos
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.scanDigits()
+		} else {
+			l.pos = save
+		}
+	}
+
+	for _, suffix := range durationSuffixes {
+		if !strings.HasPrefix(l.src[l.pos:], suffix) {
+			continue
+		}
+		end := l.pos + len(suffix)
+		if end < len(l.src) && isIdentPart(l.src[end]) {
+			continue
+		}
+		l.pos = end
+		return token{Kind: tokDuration, Text: l.src[start:l.pos], Pos: start}, nil
+	}
+
+	return l.finishNumber(start)
+}
+
+func (l *lexer) finishNumber(start int) (token, error) {
+	if l.pos < len(l.src) && isIdentPart(l.src[l.pos]) {
+		return token{}, errorAt(l.src, l.pos, fmt.Sprintf("invalid number %q", l.src[start:l.pos+1]))
+	}
+	return token{Kind: tokNumber, Text: l.src[start:l.pos], Pos: start}, nil
+}
+
+func (l *lexer) scanDigits() {
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.pos++
+	}
+}
+
+func (l *lexer) lexRegex() (token, error) {
+	start := l.pos
+	l.pos++ // opening slash
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case '\\':
+			l.pos += 2
+			continue
+		case '/':
+			l.pos++
+			return token{Kind: tokRegex, Text: l.src[start:l.pos], Pos: start}, nil
+		}
+		l.pos++
+	}
+	return token{}, errorAt(l.src, start, "unterminated regular expression")
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// isKeyword reports whether ident case-insensitively matches one of kws.
+func isKeyword(ident string, kws ...string) bool {
+	for _, kw := range kws {
+		if strings.EqualFold(ident, kw) {
+			return true
+		}
+	}
+	return false
+}
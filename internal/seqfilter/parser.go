@@ -0,0 +1,402 @@
+package seqfilter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// reservedWords cannot be used as bare property names.
+var reservedWords = []string{"and", "or", "not", "like", "in", "is", "ci"}
+
+// Parse parses a strict Seq filter expression. Errors are returned as *Error
+// with the position of the offending input.
+func Parse(src string) (Expr, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, errorAt(src, 0, "empty expression")
+	}
+
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{src: src, tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.Kind != tokEOF {
+		return nil, p.errorf(tok, "unexpected %s after end of expression", describe(tok))
+	}
+	return expr, nil
+}
+
+// Canonicalize parses src and renders it in canonical form.
+func Canonicalize(src string) (string, error) {
+	expr, err := Parse(src)
+	if err != nil {
+		return "", err
+	}
+	return expr.String(), nil
+}
+
+// Equivalent reports whether two filters are the same after
+// canonicalization. Filters that do not parse are compared as trimmed text.
+func Equivalent(a, b string) bool {
+	ca, errA := Canonicalize(a)
+	cb, errB := Canonicalize(b)
+	if errA != nil || errB != nil {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+	return ca == cb
+}
+
+type parser struct {
+	src    string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(n int) token {
+	if p.pos+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+n]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.pos]
+	if tok.Kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) errorf(tok token, format string, args ...any) error {
+	return errorAt(p.src, tok.Pos, fmt.Sprintf(format, args...))
+}
+
+// isOp reports whether tok is one of the given operators.
+func isOp(tok token, ops ...string) bool {
+	if tok.Kind != tokOperator {
+		return false
+	}
+	for _, op := range ops {
+		if tok.Text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func isPunct(tok token, text string) bool {
+	return tok.Kind == tokPunct && tok.Text == text
+}
+
+func isWord(tok token, kws ...string) bool {
+	return tok.Kind == tokIdent && isKeyword(tok.Text, kws...)
+}
+
+func (p *parser) expectPunct(text string) error {
+	tok := p.peek()
+	if !isPunct(tok, text) {
+		return p.errorf(tok, "expected '%s' but found %s", text, describe(tok))
+	}
+	p.advance()
+	return nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isWord(p.peek(), "or") || isOp(p.peek(), "||") {
+		p.advance()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: "or", L: left, R: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for isWord(p.peek(), "and") || isOp(p.peek(), "&&") {
+		p.advance()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: "and", L: left, R: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if tok := p.peek(); isWord(tok, "not") || isOp(tok, "!") {
+		p.advance()
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: "not", X: x, Offset: tok.Pos}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		var op string
+		switch {
+		case isOp(tok, "=", "==", "<>", "!=", "<", "<=", ">", ">="):
+			op = tok.Text
+			switch op {
+			case "==":
+				op = "="
+			case "!=":
+				op = "<>"
+			}
+			p.advance()
+		case isWord(tok, "like", "in"):
+			op = strings.ToLower(tok.Text)
+			p.advance()
+		case isWord(tok, "not") && isWord(p.peekAt(1), "like", "in"):
+			op = "not " + strings.ToLower(p.peekAt(1).Text)
+			p.advance()
+			p.advance()
+		case isWord(tok, "is"):
+			p.advance()
+			not := false
+			if isWord(p.peek(), "not") {
+				p.advance()
+				not = true
+			}
+			if !isWord(p.peek(), "null") {
+				return nil, p.errorf(p.peek(), "expected 'null' but found %s", describe(p.peek()))
+			}
+			p.advance()
+			left = &IsNull{X: left, Not: not}
+			continue
+		default:
+			return left, nil
+		}
+
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		b := &Binary{Op: op, L: left, R: right}
+		if isWord(p.peek(), "ci") {
+			p.advance()
+			b.CI = true
+		}
+		left = b
+	}
+}
+
+func (p *parser) parseAdditive() (Expr, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for isOp(p.peek(), "+", "-") {
+		op := p.advance().Text
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, L: left, R: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseMultiplicative() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for isOp(p.peek(), "*", "/", "%") {
+		op := p.advance().Text
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, L: left, R: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if tok := p.peek(); isOp(tok, "-") {
+		p.advance()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: "-", X: x, Offset: tok.Pos}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (Expr, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		switch {
+		case isPunct(tok, "."):
+			p.advance()
+			name := p.peek()
+			if name.Kind != tokIdent {
+				return nil, p.errorf(name, "expected a property name after '.' but found %s", describe(name))
+			}
+			p.advance()
+			x = &Member{Target: x, Name: name.Text}
+		case isPunct(tok, "["):
+			p.advance()
+			idx := &Index{Target: x}
+			if next := p.peek(); (isPunct(next, "?") || isOp(next, "*")) && isPunct(p.peekAt(1), "]") {
+				idx.Wildcard = next.Text
+				p.advance()
+			} else {
+				idx.Index, err = p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+			}
+			if err := p.expectPunct("]"); err != nil {
+				return nil, err
+			}
+			x = idx
+		default:
+			return x, nil
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.peek()
+	switch tok.Kind {
+	case tokString:
+		p.advance()
+		return &Literal{Kind: LiteralString, Value: tok.Text, Offset: tok.Pos}, nil
+	case tokNumber:
+		p.advance()
+		return &Literal{Kind: LiteralNumber, Value: tok.Text, Offset: tok.Pos}, nil
+	case tokDuration:
+		p.advance()
+		return &Literal{Kind: LiteralDuration, Value: tok.Text, Offset: tok.Pos}, nil
+	case tokRegex:
+		p.advance()
+		return &Literal{Kind: LiteralRegex, Value: tok.Text, Offset: tok.Pos}, nil
+	case tokBuiltin:
+		p.advance()
+		return &Property{Name: tok.Text, Offset: tok.Pos}, nil
+	case tokIdent:
+		return p.parseIdent()
+	case tokEOF:
+		return nil, p.errorf(tok, "unexpected end of input")
+	}
+
+	switch {
+	case isPunct(tok, "("):
+		p.advance()
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return nil, err
+		}
+		return x, nil
+	case isPunct(tok, "["):
+		p.advance()
+		elems, err := p.parseList("]")
+		if err != nil {
+			return nil, err
+		}
+		return &Array{Elems: elems, Offset: tok.Pos}, nil
+	}
+
+	return nil, p.errorf(tok, "unexpected %s", describe(tok))
+}
+
+func (p *parser) parseIdent() (Expr, error) {
+	tok := p.advance()
+	switch {
+	case isWord(tok, "true", "false"):
+		return &Literal{Kind: LiteralBool, Value: strings.ToLower(tok.Text), Offset: tok.Pos}, nil
+	case isWord(tok, "null"):
+		return &Literal{Kind: LiteralNull, Value: "null", Offset: tok.Pos}, nil
+	case isWord(tok, reservedWords...):
+		return nil, p.errorf(tok, "unexpected keyword '%s'", strings.ToLower(tok.Text))
+	}
+
+	if isPunct(p.peek(), "(") {
+		p.advance()
+		args, err := p.parseList(")")
+		if err != nil {
+			return nil, err
+		}
+		return &Call{Name: tok.Text, Args: args, Offset: tok.Pos}, nil
+	}
+	return &Property{Name: tok.Text, Offset: tok.Pos}, nil
+}
+
+// parseList parses comma-separated expressions up to and including the
+// closing punctuation.
+func (p *parser) parseList(closing string) ([]Expr, error) {
+	var out []Expr
+	if isPunct(p.peek(), closing) {
+		p.advance()
+		return out, nil
+	}
+	for {
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, x)
+		if isPunct(p.peek(), ",") {
+			p.advance()
+			continue
+		}
+		if err := p.expectPunct(closing); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+}
+
+// describe renders a token for use in error messages.
+func describe(tok token) string {
+	switch tok.Kind {
+	case tokEOF:
+		return tok.Kind.String()
+	case tokString:
+		return "string " + QuoteString(tok.Text)
+	case tokOperator, tokPunct:
+		return fmt.Sprintf("'%s'", tok.Text)
+	default:
+		return fmt.Sprintf("%s '%s'", tok.Kind, tok.Text)
+	}
+}
@@ -0,0 +1,117 @@
+package seqfilter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"@Level = 'Error'", "@Level = 'Error'"},
+		{"@Level=='Error'   OR @Level == 'Fatal'", "@Level = 'Error' or @Level = 'Fatal'"},
+		{"Application != 'x' && !Has(Tenant)", "Application <> 'x' and not Has(Tenant)"},
+		{"(A = 1 and B = 2) or C = 3", "A = 1 and B = 2 or C = 3"},
+		{"A = 1 and (B = 2 or C = 3)", "A = 1 and (B = 2 or C = 3)"},
+		{"not (A = 1 or B = 2)", "not (A = 1 or B = 2)"},
+		{"@Message like '%timeout%' ci", "@Message like '%timeout%' ci"},
+		{"Environment NOT IN ['dev', 'test']", "Environment not in ['dev', 'test']"},
+		{"@Exception is not null", "@Exception is not null"},
+		{"Name = 'O''Brien'", "Name = 'O''Brien'"},
+		{"Elapsed > 1.5e3 * (2 + 3)", "Elapsed > 1.5e3 * (2 + 3)"},
+		{"(Elapsed - 1) - 2", "Elapsed - 1 - 2"},
+		{"Elapsed - (1 - 2)", "Elapsed - (1 - 2)"},
+		{"-(A + 1) > 0", "-(A + 1) > 0"},
+		{"@Timestamp > Now() - 1d", "@Timestamp > Now() - 1d"},
+		{"Duration < 250ms", "Duration < 250ms"},
+		{"@Message = /fail(ed|ure)/", "@Message = /fail(ed|ure)/"},
+		{"Total / 2 > 1", "Total / 2 > 1"},
+		{"Request.Path[0] = 'api' and @Properties['user id'] = 7", "Request.Path[0] = 'api' and @Properties['user id'] = 7"},
+		{"Tags[?] = 'x' and Items[*].Ok = TRUE", "Tags[?] = 'x' and Items[*].Ok = true"},
+		{"StartsWith(@Message,'a')", "StartsWith(@Message, 'a')"},
+		{"Flags = 0xFF", "Flags = 0xFF"},
+		{"A\n  and\n  B", "A and B"},
+	}
+
+	for _, tc := range cases {
+		got, err := Canonicalize(tc.in)
+		if err != nil {
+			t.Fatalf("Canonicalize(%q) error: %v", tc.in, err)
+		}
+		if got != tc.want {
+			t.Fatalf("Canonicalize(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+
+		// Canonical output must itself be a fixed point.
+		again, err := Canonicalize(got)
+		if err != nil || again != got {
+			t.Fatalf("Canonicalize(%q) is not stable: %q, %v", got, again, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		in     string
+		line   int
+		column int
+		msg    string
+	}{
+		{"", 1, 1, "empty expression"},
+		{"@Level = 'Error", 1, 10, "unterminated string literal"},
+		{"@Level = ", 1, 10, "unexpected end of input"},
+		{"Hello world", 1, 7, "unexpected identifier 'world' after end of expression"},
+		{"A = \"x\"", 1, 5, "strings must be delimited with single quotes"},
+		{"A in [1, 2", 1, 11, "expected ']' but found end of input"},
+		{"A = 1\nand or B", 2, 5, "unexpected keyword 'or'"},
+		{"A is 3", 1, 6, "expected 'null' but found number '3'"},
+		{"Count > 12abc", 1, 11, "invalid number \"12a\""},
+		{"@ = 1", 1, 1, "expected a property name after '@'"},
+		{"Items[?] => x", 1, 10, "'=>' is not supported in Seq filter expressions"},
+	}
+
+	for _, tc := range cases {
+		_, err := Parse(tc.in)
+		var perr *Error
+		if !errors.As(err, &perr) {
+			t.Fatalf("Parse(%q) expected *Error, got %v", tc.in, err)
+		}
+		if perr.Line != tc.line || perr.Column != tc.column || perr.Msg != tc.msg {
+			t.Fatalf("Parse(%q) = %d:%d %q, want %d:%d %q", tc.in, perr.Line, perr.Column, perr.Msg, tc.line, tc.column, tc.msg)
+		}
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	if !Equivalent("@Level == 'Error' || @Level == 'Fatal'", "@Level = 'Error' or @Level = 'Fatal'") {
+		t.Fatalf("expected filters to be equivalent")
+	}
+	if Equivalent("@Level = 'Error'", "@Level = 'Fatal'") {
+		t.Fatalf("expected filters to differ")
+	}
+	// Free-text filters that do not parse fall back to text comparison.
+	if !Equivalent(" connection refused ", "connection refused") {
+		t.Fatalf("expected free-text filters to be equivalent")
+	}
+}
+
+func TestPropertyAccessor(t *testing.T) {
+	cases := map[string]string{
+		"Application": "Application",
+		"@Level":      "@Level",
+		"user id":     "@Properties['user id']",
+		"O'Brien":     "@Properties['O''Brien']",
+		"and":         "@Properties['and']",
+		"1st":         "@Properties['1st']",
+	}
+	for in, want := range cases {
+		if got := PropertyAccessor(in); got != want {
+			t.Fatalf("PropertyAccessor(%q) = %q, want %q", in, got, want)
+		}
+		if _, err := Parse(PropertyAccessor(in) + " = 1"); err != nil {
+			t.Fatalf("PropertyAccessor(%q) does not parse: %v", in, err)
+		}
+	}
+}
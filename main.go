@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
@@ -12,8 +13,27 @@ import (
 
 var version = "dev"
 
-// terraform-provider-seq entrypoint.
+// terraform-provider-seq entrypoint. `terraform-provider-seq export` writes
+// configuration for an existing Seq server instead of serving the provider.
 func main() {
+	shutdownTracing, err := provider.SetupTracing(context.Background(), version)
+	if err != nil {
+		log.Printf("[WARN] OpenTelemetry tracing disabled: %s", err)
+	}
+	os.Exit(run(shutdownTracing))
+}
+
+func run(shutdownTracing func(context.Context) error) int {
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Printf("[WARN] Flushing OpenTelemetry spans failed: %s", err)
+		}
+	}()
+
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		return runExport(os.Args[2:], os.Stdout, os.Stderr)
+	}
+
 	var debug bool
 	flag.BoolVar(&debug, "debug", false, "start provider in debug mode")
 	flag.Parse()
@@ -24,6 +44,8 @@ func main() {
 	}
 
 	if err := providerserver.Serve(context.Background(), provider.New(version), opts); err != nil {
-		log.Fatal(err)
+		log.Print(err)
+		return 1
 	}
+	return 0
 }
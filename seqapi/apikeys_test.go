@@ -0,0 +1,119 @@
+package seqapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/alexdresko/terraform-provider-seq/internal/seqfake"
+)
+
+func TestAPIKeyRequestBody(t *testing.T) {
+	level := "Warning"
+	key := APIKey{
+		ID:          "apikey-123",
+		Title:       "x",
+		Permissions: []string{"Read"},
+		InputSettings: &InputSettings{
+			MinimumLevel:      &level,
+			Filter:            &DescriptiveFilter{Filter: "Level = 'Error'", FilterNonStrict: "Level = Error"},
+			AppliedProperties: []EventProperty{{Name: "Port", Value: 8080}},
+		},
+	}
+
+	b, err := json.Marshal(apiKeyRequestBody(key, "Permissions"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Id":"apikey-123","InputSettings":{"AppliedProperties":[{"Name":"Port","Value":8080}],"Filter":{"Filter":"Level = 'Error'","FilterNonStrict":"Level = Error"},"MinimumLevel":"Warning"},"Permissions":["Read"],"Title":"x"}`
+	if string(b) != want {
+		t.Fatalf("unexpected body:\n got %s\nwant %s", b, want)
+	}
+}
+
+func TestAPIKeyRequestBodyOmitsUnsetValues(t *testing.T) {
+	body := apiKeyRequestBody(APIKey{Title: "x", InputSettings: &InputSettings{}}, "AssignedPermissions")
+	if len(body) != 1 || body["Title"] != "x" {
+		t.Fatalf("expected only the title, got %v", body)
+	}
+
+	// An empty list clears the applied properties rather than leaving them out.
+	body = apiKeyRequestBody(APIKey{Title: "x", Permissions: []string{}, InputSettings: &InputSettings{AppliedProperties: []EventProperty{}}}, "AssignedPermissions")
+	b, _ := json.Marshal(body)
+	if want := `{"AssignedPermissions":[],"InputSettings":{"AppliedProperties":[]},"Title":"x"}`; string(b) != want {
+		t.Fatalf("unexpected body:\n got %s\nwant %s", b, want)
+	}
+}
+
+func TestAPIKeyReadsEitherPermissionsField(t *testing.T) {
+	for _, raw := range []string{
+		`{"Id":"apikey-1","Title":"x","AssignedPermissions":["Ingest"]}`,
+		`{"Id":"apikey-1","Title":"x","Permissions":["Ingest"]}`,
+	} {
+		var key APIKey
+		if err := json.Unmarshal([]byte(raw), &key); err != nil {
+			t.Fatal(err)
+		}
+		if key.ID != "apikey-1" || key.Title != "x" || len(key.Permissions) != 1 || key.Permissions[0] != "Ingest" {
+			t.Fatalf("decoded %s as %+v", raw, key)
+		}
+	}
+}
+
+func TestAPIKeyMethods(t *testing.T) {
+	for _, version := range []string{seqfake.DefaultVersion, "2020.5.4778"} {
+		t.Run(version, func(t *testing.T) {
+			ctx := context.Background()
+			fake := seqfake.New(t, seqfake.WithVersion(version))
+			c, err := New(Config{ServerURL: fake.URL, HTTPClient: fake.Client()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := c.DetectCapabilities(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			created, err := c.CreateAPIKey(ctx, APIKey{Title: "ingest", Permissions: []string{"Ingest"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if created.ID == "" || created.Token == "" {
+				t.Fatalf("expected an id and token, got %+v", created)
+			}
+			// The fake only accepts permissions in the field its version uses.
+			if len(created.Permissions) != 1 || created.Permissions[0] != "Ingest" {
+				t.Fatalf("expected the Ingest permission to be stored, got %v", created.Permissions)
+			}
+
+			created.Title = "renamed"
+			if _, err := c.UpdateAPIKey(ctx, created); err != nil {
+				t.Fatal(err)
+			}
+			got, err := c.GetAPIKey(ctx, created.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Title != "renamed" || len(got.Permissions) != 1 || got.Token != "" {
+				t.Fatalf("unexpected key after update: %+v", got)
+			}
+
+			var listed []string
+			for key, err := range c.ListAPIKeys(ctx) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				listed = append(listed, key.ID)
+			}
+			if len(listed) != 1 || listed[0] != created.ID {
+				t.Fatalf("listed %v", listed)
+			}
+
+			if err := c.DeleteAPIKey(ctx, created.ID); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := c.GetAPIKey(ctx, created.ID); !IsNotFound(err) {
+				t.Fatalf("expected not found after delete, got %v", err)
+			}
+		})
+	}
+}
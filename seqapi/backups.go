@@ -0,0 +1,60 @@
+package seqapi
+
+import (
+	"context"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// Backup is a server backup.
+//
+// Ref: https://datalust.co/docs/server-http-api#api-backups
+type Backup struct {
+	ID        string `json:"Id"`
+	Filename  string `json:"Filename"`
+	CreatedAt string `json:"CreatedAt"`
+	SizeBytes int64  `json:"SizeBytes"`
+}
+
+// ListBackups iterates over the retained backups.
+func (c *Client) ListBackups(ctx context.Context) iter.Seq2[Backup, error] {
+	path, err := c.ResourcePath(ResourceBackups)
+	if err != nil {
+		return failedList[Backup](err)
+	}
+	return ListItems[Backup](ctx, c, path)
+}
+
+// GetBackup reads a backup by id.
+func (c *Client) GetBackup(ctx context.Context, id string) (Backup, error) {
+	var backup Backup
+	path, err := c.ResourcePath(ResourceBackups)
+	if err != nil {
+		return backup, err
+	}
+	err = c.GetEntity(ctx, path, id, &backup)
+	return backup, err
+}
+
+// CreateBackup takes a backup now.
+func (c *Client) CreateBackup(ctx context.Context) (Backup, error) {
+	var created Backup
+	path, err := c.ResourcePath(ResourceBackups)
+	if err != nil {
+		return created, err
+	}
+	err = c.DoJSON(ctx, http.MethodPost, path, map[string]any{}, &created)
+	return created, err
+}
+
+// DownloadBackup streams the backup file to w, returning the number of bytes
+// written.
+func (c *Client) DownloadBackup(ctx context.Context, id string, w io.Writer) (int64, error) {
+	path, err := c.ResourcePath(ResourceBackups)
+	if err != nil {
+		return 0, err
+	}
+	return c.Download(ctx, path+"/"+url.PathEscape(id)+"/download", w)
+}
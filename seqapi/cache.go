@@ -0,0 +1,112 @@
+package seqapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// collectionCache serves entity reads from one listing of each collection per
+// client, so refreshing many resources of one type costs a single request.
+// Writes to a collection invalidate its listing. A nil *collectionCache
+// disables caching.
+type collectionCache struct {
+	mu          sync.Mutex
+	collections map[string]*cachedCollection
+}
+
+type cachedCollection struct {
+	mu       sync.Mutex
+	loaded   bool
+	entities map[string]json.RawMessage
+}
+
+func newCollectionCache() *collectionCache {
+	return &collectionCache{collections: map[string]*cachedCollection{}}
+}
+
+// collection returns the cache entry for a collection path, creating it if
+// needed.
+func (cc *collectionCache) collection(path string) *cachedCollection {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	entry, ok := cc.collections[path]
+	if !ok {
+		entry = &cachedCollection{}
+		cc.collections[path] = entry
+	}
+	return entry
+}
+
+// invalidate drops the cached listing of the collection containing path.
+func (cc *collectionCache) invalidate(path string) {
+	if cc == nil {
+		return
+	}
+	collection := collectionPath(path)
+	if collection == "" {
+		return
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	delete(cc.collections, collection)
+}
+
+// collectionPath returns the collection a path belongs to, e.g. /api/apikeys
+// for /api/apikeys/apikey-1/metrics, or "" for paths outside /api.
+func collectionPath(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if len(parts) < 2 || parts[0] != "api" || parts[1] == "" {
+		return ""
+	}
+	return "/api/" + parts[1]
+}
+
+// GetEntity reads collection/{id}, e.g. /api/apikeys/apikey-1, into out. When
+// read caching is enabled, the collection is listed once and entities are
+// served from the listing; entities missing from the listing (e.g. ones it
+// does not include for the current user) are still read individually.
+func (c *Client) GetEntity(ctx context.Context, collection, id string, out any) error {
+	entityPath := collection + "/" + url.PathEscape(id)
+	if c.cache == nil {
+		return c.DoJSON(ctx, http.MethodGet, entityPath, nil, out)
+	}
+
+	entry := c.cache.collection(collection)
+	entry.mu.Lock()
+	if !entry.loaded {
+		var items []json.RawMessage
+		if err := c.DoJSON(ctx, http.MethodGet, collection, nil, &items); err != nil {
+			entry.mu.Unlock()
+			tflog.Debug(ctx, "Listing Seq collection for the read cache failed; reading entity directly", map[string]any{
+				"collection": collection,
+				"error":      err.Error(),
+			})
+			return c.DoJSON(ctx, http.MethodGet, entityPath, nil, out)
+		}
+		entry.entities = make(map[string]json.RawMessage, len(items))
+		for _, item := range items {
+			var ref struct {
+				ID string `json:"Id"`
+			}
+			if json.Unmarshal(item, &ref) == nil && ref.ID != "" {
+				entry.entities[ref.ID] = item
+			}
+		}
+		entry.loaded = true
+		tflog.Debug(ctx, "Cached Seq collection", map[string]any{"collection": collection, "count": len(entry.entities)})
+	}
+	raw, ok := entry.entities[id]
+	entry.mu.Unlock()
+
+	if !ok {
+		return c.DoJSON(ctx, http.MethodGet, entityPath, nil, out)
+	}
+	return json.Unmarshal(raw, out)
+}
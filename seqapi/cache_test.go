@@ -0,0 +1,159 @@
+package seqapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestGetEntityServesReadsFromCollectionListing(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/apikeys":
+			_, _ = w.Write([]byte(`[{"Id": "apikey-1", "Title": "one"}, {"Id": "apikey-2", "Title": "two"}]`))
+		case "GET /api/apikeys/apikey-3":
+			_, _ = w.Write([]byte(`{"Id": "apikey-3", "Title": "three"}`))
+		case "PUT /api/apikeys/apikey-1":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: mustParseURL(srv.URL), http: srv.Client(), cache: newCollectionCache()}
+	ctx := context.Background()
+
+	read := func(id string) APIKey {
+		t.Helper()
+		var got APIKey
+		if err := c.GetEntity(ctx, "/api/apikeys", id, &got); err != nil {
+			t.Fatalf("GetEntity(%s): %v", id, err)
+		}
+		return got
+	}
+
+	if read("apikey-1").Title != "one" || read("apikey-2").Title != "two" {
+		t.Fatalf("unexpected cached entities")
+	}
+	// Entities missing from the listing are read individually.
+	if read("apikey-3").Title != "three" {
+		t.Fatalf("unexpected fallback entity")
+	}
+	if err := c.GetEntity(ctx, "/api/apikeys", "apikey-404", &APIKey{}); !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	// Writes invalidate the listing.
+	if err := c.DoJSON(ctx, http.MethodPut, "/api/apikeys/apikey-1", map[string]any{}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	read("apikey-2")
+
+	want := []string{
+		"GET /api/apikeys",
+		"GET /api/apikeys/apikey-3",
+		"GET /api/apikeys/apikey-404",
+		"PUT /api/apikeys/apikey-1",
+		"GET /api/apikeys",
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("unexpected requests %v", requests)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Fatalf("unexpected requests %v", requests)
+		}
+	}
+}
+
+func TestWriteInvalidatesListingReadWhileInFlight(t *testing.T) {
+	var mu sync.Mutex
+	title := "before"
+	writing, listed := make(chan struct{}), make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/apikeys":
+			mu.Lock()
+			defer mu.Unlock()
+			_, _ = w.Write([]byte(`[{"Id": "apikey-1", "Title": "` + title + `"}]`))
+		case "PUT /api/apikeys/apikey-1":
+			// Apply the write only after a listing has been served
+			// while it was in flight.
+			close(writing)
+			<-listed
+			mu.Lock()
+			title = "after"
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: mustParseURL(srv.URL), http: srv.Client(), cache: newCollectionCache()}
+	ctx := context.Background()
+
+	written := make(chan error, 1)
+	go func() {
+		written <- c.DoJSON(ctx, http.MethodPut, "/api/apikeys/apikey-1", map[string]any{}, nil)
+	}()
+
+	<-writing
+	var got APIKey
+	if err := c.GetEntity(ctx, "/api/apikeys", "apikey-1", &got); err != nil {
+		t.Fatalf("GetEntity during write: %v", err)
+	}
+	close(listed)
+	if err := <-written; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.GetEntity(ctx, "/api/apikeys", "apikey-1", &got); err != nil {
+		t.Fatalf("GetEntity after write: %v", err)
+	}
+	if got.Title != "after" {
+		t.Fatalf("expected the listing read during the write to be invalidated, got title %q", got.Title)
+	}
+}
+
+func TestGetEntityWithoutCacheReadsDirectly(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"Id": "apikey-1"}`))
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: mustParseURL(srv.URL), http: srv.Client()}
+	var got APIKey
+	if err := c.GetEntity(context.Background(), "/api/apikeys", "apikey-1", &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(paths) != 1 || paths[0] != "/api/apikeys/apikey-1" {
+		t.Fatalf("unexpected requests %v", paths)
+	}
+}
+
+func TestCollectionPath(t *testing.T) {
+	cases := map[string]string{
+		"/api/apikeys":                   "/api/apikeys",
+		"/api/apikeys/apikey-1":          "/api/apikeys",
+		"/api/apikeys/apikey-1/metrics":  "/api/apikeys",
+		"api/permalinks/p-1?render=true": "/api/permalinks",
+		"/health":                        "",
+		"/ingest/clef":                   "",
+	}
+	for in, want := range cases {
+		if got := collectionPath(in); got != want {
+			t.Fatalf("collectionPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
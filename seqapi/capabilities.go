@@ -0,0 +1,195 @@
+package seqapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// apiRootResponse is the API root document returned by GET /api.
+type apiRootResponse struct {
+	Product string            `json:"Product"`
+	Version string            `json:"Version"`
+	Links   map[string]string `json:"Links"`
+}
+
+// serverCapabilities records which Seq API variations the connected server
+// uses. The zero value describes a current Seq version, which is also what
+// the client assumes when the version cannot be determined.
+type serverCapabilities struct {
+	version string
+	// legacyAPIKeyPermissions is set for servers that predate the
+	// AssignedPermissions field on API keys and use Permissions instead.
+	legacyAPIKeyPermissions bool
+	// resources maps the resource groups listed in the API root's Links to
+	// their collection paths. It is nil when the server listed none, in
+	// which case every group is assumed to be at its default path.
+	resources map[string]string
+}
+
+// Resource groups as named in the Links of the API root document, e.g.
+// "ApiKeysResources" for ResourceAPIKeys.
+const (
+	ResourceAPIKeys    = "ApiKeys"
+	ResourcePermalinks = "Permalinks"
+	ResourceBackups    = "Backups"
+	ResourceSettings   = "Settings"
+	ResourceEvents     = "Events"
+	ResourceData       = "Data"
+	ResourceAlertState = "AlertState"
+)
+
+// resourceGroups gives the default collection path of each resource group
+// and how to name it in errors.
+var resourceGroups = map[string]struct{ path, noun string }{
+	ResourceAPIKeys:    {"/api/apikeys", "API keys"},
+	ResourcePermalinks: {"/api/permalinks", "permalinks"},
+	ResourceBackups:    {"/api/backups", "backups"},
+	ResourceSettings:   {"/api/settings", "server settings"},
+	ResourceEvents:     {"/api/events", "event search"},
+	ResourceData:       {"/api/data", "SQL queries"},
+	ResourceAlertState: {"/api/alertstate", "alert state"},
+}
+
+// UnsupportedError is returned for a resource group the server does not
+// offer, e.g. permalinks on a Seq version that predates them.
+type UnsupportedError struct {
+	Resource string
+	Version  string
+}
+
+func (e *UnsupportedError) Error() string {
+	noun := e.Resource
+	if g, ok := resourceGroups[e.Resource]; ok {
+		noun = g.noun
+	}
+	return fmt.Sprintf("the Seq server (version %s) does not support %s: its API root document lists no %sResources", e.Version, noun, e.Resource)
+}
+
+// IsUnsupported reports whether err is an *UnsupportedError.
+func IsUnsupported(err error) bool {
+	var ue *UnsupportedError
+	return errors.As(err, &ue)
+}
+
+// ResourcePath returns the collection path of a resource group, such as
+// /api/apikeys for ResourceAPIKeys, as listed by the server's API root
+// document. If the server lists its resource groups but not this one, an
+// *UnsupportedError is returned. Before DetectCapabilities, or if the server
+// lists none, the default path is assumed.
+func (c *Client) ResourcePath(resource string) (string, error) {
+	if path, ok := c.caps.resources[resource]; ok {
+		return path, nil
+	}
+	if c.caps.resources != nil {
+		return "", &UnsupportedError{Resource: resource, Version: c.caps.version}
+	}
+	if g, ok := resourceGroups[resource]; ok {
+		return g.path, nil
+	}
+	return "", fmt.Errorf("unknown Seq resource group %q", resource)
+}
+
+// resourcePaths reads the resource groups from the API root's Links, e.g.
+// "ApiKeysResources": "api/apikeys/resources" gives ApiKeys at /api/apikeys.
+func resourcePaths(links map[string]string) map[string]string {
+	var paths map[string]string
+	for name, link := range links {
+		group, ok := strings.CutSuffix(name, "Resources")
+		if !ok || group == "" {
+			continue
+		}
+		link, _, _ = strings.Cut(link, "{")
+		link, _, _ = strings.Cut(link, "?")
+		path := "/" + strings.Trim(strings.TrimSuffix(strings.TrimSuffix(link, "/"), "/resources"), "/")
+		if path == "/" {
+			continue
+		}
+		if paths == nil {
+			paths = map[string]string{}
+		}
+		paths[group] = path
+	}
+	return paths
+}
+
+// assignedPermissionsSince is the first Seq version whose API keys use
+// AssignedPermissions.
+var assignedPermissionsSince = seqVersion{major: 2021, minor: 1}
+
+// apiKeyPermissionsField returns the API key field that carries permissions.
+func (c serverCapabilities) apiKeyPermissionsField() string {
+	if c.legacyAPIKeyPermissions {
+		return "Permissions"
+	}
+	return "AssignedPermissions"
+}
+
+// DetectCapabilities reads the server's version from the API root document
+// and adapts later requests to it. On failure the client keeps assuming a
+// current Seq version and the error is returned.
+func (c *Client) DetectCapabilities(ctx context.Context) error {
+	var root apiRootResponse
+	if err := c.DoJSON(ctx, http.MethodGet, "/api", nil, &root); err != nil {
+		return err
+	}
+	caps, err := capabilitiesForVersion(root.Version)
+	caps.resources = resourcePaths(root.Links)
+	c.caps = caps
+	return err
+}
+
+// ServerVersion returns the version found by DetectCapabilities, if any.
+func (c *Client) ServerVersion() string {
+	return c.caps.version
+}
+
+// APIKeyPermissionsField returns the API key field that carries permissions
+// on the server: AssignedPermissions, or Permissions before Seq 2021.1.
+func (c *Client) APIKeyPermissionsField() string {
+	return c.caps.apiKeyPermissionsField()
+}
+
+func capabilitiesForVersion(raw string) (serverCapabilities, error) {
+	v, err := parseSeqVersion(raw)
+	if err != nil {
+		return serverCapabilities{version: raw}, err
+	}
+	return serverCapabilities{
+		version:                 raw,
+		legacyAPIKeyPermissions: v.less(assignedPermissionsSince),
+	}, nil
+}
+
+// seqVersion is a Seq release version such as 2024.3.12345 (or 5.1.3364 for
+// releases before calendar versioning).
+type seqVersion struct {
+	major int
+	minor int
+}
+
+func parseSeqVersion(raw string) (seqVersion, error) {
+	parts := strings.Split(strings.TrimSpace(raw), ".")
+	if len(parts) < 2 {
+		return seqVersion{}, fmt.Errorf("unrecognized Seq version %q", raw)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return seqVersion{}, fmt.Errorf("unrecognized Seq version %q", raw)
+	}
+	minor, err := strconv.Atoi(strings.SplitN(parts[1], "-", 2)[0])
+	if err != nil {
+		return seqVersion{}, fmt.Errorf("unrecognized Seq version %q", raw)
+	}
+	return seqVersion{major: major, minor: minor}, nil
+}
+
+func (v seqVersion) less(o seqVersion) bool {
+	if v.major != o.major {
+		return v.major < o.major
+	}
+	return v.minor < o.minor
+}
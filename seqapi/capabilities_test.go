@@ -0,0 +1,63 @@
+package seqapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexdresko/terraform-provider-seq/internal/seqfake"
+)
+
+func TestResourcePaths(t *testing.T) {
+	got := resourcePaths(map[string]string{
+		"ApiKeysResources":    "api/apikeys/resources",
+		"PermalinksResources": "api/permalinks/resources{?x}",
+		"Self":                "api/",
+		"Resources":           "api/resources",
+	})
+	if len(got) != 2 || got[ResourceAPIKeys] != "/api/apikeys" || got[ResourcePermalinks] != "/api/permalinks" {
+		t.Fatalf("resourcePaths() = %v", got)
+	}
+	if resourcePaths(map[string]string{}) != nil {
+		t.Fatal("expected no resource groups for empty links")
+	}
+}
+
+func TestResourcePathReportsUnsupportedResources(t *testing.T) {
+	ctx := context.Background()
+	fake := seqfake.New(t, seqfake.WithVersion("5.1.3364"))
+	c, err := New(Config{ServerURL: fake.URL, HTTPClient: fake.Client()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Before detection every resource group is assumed to exist.
+	if path, err := c.ResourcePath(ResourcePermalinks); err != nil || path != "/api/permalinks" {
+		t.Fatalf("ResourcePath() = %q, %v", path, err)
+	}
+
+	if err := c.DetectCapabilities(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if path, err := c.ResourcePath(ResourceAPIKeys); err != nil || path != "/api/apikeys" {
+		t.Fatalf("ResourcePath(ApiKeys) = %q, %v", path, err)
+	}
+
+	// The fake only lists the collections it serves, so permalinks are
+	// rejected without a request.
+	requests := len(fake.Requests())
+	_, err = c.CreatePermalink(ctx, "event-1")
+	if !IsUnsupported(err) {
+		t.Fatalf("expected an unsupported error, got %v", err)
+	}
+	if want := "the Seq server (version 5.1.3364) does not support permalinks: its API root document lists no PermalinksResources"; err.Error() != want {
+		t.Fatalf("error = %q, want %q", err, want)
+	}
+	for _, err := range c.ListPermalinks(ctx) {
+		if !IsUnsupported(err) {
+			t.Fatalf("expected an unsupported error from the listing, got %v", err)
+		}
+	}
+	if got := len(fake.Requests()); got != requests {
+		t.Fatalf("expected no requests for an unsupported resource, got %v", fake.Requests()[requests:])
+	}
+}
@@ -0,0 +1,486 @@
+package seqapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Cassettes capture Seq API traffic so problems can be reproduced without the
+// reporter's server. Recording appends every request/response pair to a file,
+// with API keys, tokens, passwords and secrets redacted; replaying serves
+// responses from such a file instead of contacting Seq. Cassettes are JSON
+// Lines, one interaction per line, so several processes (such as the separate
+// provider processes of a Terraform run) can append to the same file.
+// Response bodies that are binary or larger than maxInlineCassetteBody, such
+// as backup downloads, are streamed to files in a directory beside the
+// cassette (see cassetteBodyDir) rather than held in memory and written into
+// the line. Large text bodies are redacted as they are streamed; binary ones
+// are written as they are.
+
+// maxInlineCassetteBody is the largest response body recorded in the
+// cassette line itself. It keeps lines well below the limit loadCassette
+// reads, even when escaping makes the body several times longer.
+const maxInlineCassetteBody = 8 << 20
+
+// maxCassetteLine is the longest cassette line loadCassette reads.
+const maxCassetteLine = 64 << 20
+
+// redactChunk is how much of a large text body redactingWriter buffers
+// before redacting and writing it, and redactOverlap how much of that it
+// holds back in case a secret continues into the next chunk.
+const (
+	redactChunk   = 1 << 20
+	redactOverlap = 64 << 10
+)
+
+// errUnredactable reports a secret field value too long to hold in memory
+// while redacting a streamed body.
+var errUnredactable = errors.New("a secret field is too long to redact")
+
+// cassetteInteraction is one recorded request and its response. Path is
+// relative to the server URL, so a cassette can be replayed against any
+// server URL.
+type cassetteInteraction struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	RequestBody string `json:"request_body,omitempty"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body,omitempty"`
+	// BodyFile names the file holding a response body that is binary or
+	// larger than maxInlineCassetteBody, relative to the cassette's
+	// directory. Text bodies are redacted; binary ones are not.
+	BodyFile string `json:"body_file,omitempty"`
+}
+
+// cassetteBodyDir returns the directory beside a cassette that holds its
+// body files, e.g. seq.jsonl.bodies for seq.jsonl.
+func cassetteBodyDir(cassette string) string {
+	return cassette + ".bodies"
+}
+
+// cassetteTransport wraps next to record to, or replay from, a cassette file.
+func cassetteTransport(next http.RoundTripper, baseURL *url.URL, record, replay string) (http.RoundTripper, error) {
+	switch {
+	case record != "" && replay != "":
+		return nil, errors.New("a cassette cannot be recorded and replayed at the same time")
+	case record != "":
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		return &cassetteRecorder{next: next, baseURL: baseURL, file: record}, nil
+	default:
+		interactions, err := loadCassette(replay)
+		if err != nil {
+			return nil, err
+		}
+		return &cassetteReplayer{baseURL: baseURL, dir: filepath.Dir(replay), interactions: interactions, used: make([]bool, len(interactions))}, nil
+	}
+}
+
+// cassettePath returns the request path and query relative to the server URL.
+func cassettePath(baseURL *url.URL, u *url.URL) string {
+	return "/" + strings.TrimPrefix(u.RequestURI(), strings.TrimSuffix(baseURL.Path, "/")+"/")
+}
+
+// cassetteRecorder passes requests through to Seq and appends each exchange
+// to a cassette. Response bodies are recorded as the caller reads them, and
+// the exchange is appended once the body has been read or closed.
+type cassetteRecorder struct {
+	next    http.RoundTripper
+	baseURL *url.URL
+	file    string
+
+	mu sync.Mutex
+}
+
+func (r *cassetteRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		reqBody, err = io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := requestSecrets(req.Header.Get("X-Seq-ApiKey"))
+	resp.Body = &recordingBody{
+		ReadCloser: resp.Body,
+		recorder:   r,
+		secrets:    secrets,
+		interaction: cassetteInteraction{
+			Method:      req.Method,
+			Path:        redactSecrets(cassettePath(r.baseURL, req.URL), secrets...),
+			RequestBody: redactSecrets(string(reqBody), secrets...),
+			Status:      resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+		},
+	}
+	return resp, nil
+}
+
+// recordingBody copies a response body into its interaction as it is read,
+// keeping it in memory while it may be recorded inline and moving it to a
+// body file once it grows too large.
+type recordingBody struct {
+	io.ReadCloser
+	recorder    *cassetteRecorder
+	secrets     []string
+	interaction cassetteInteraction
+
+	inline []byte
+	spill  *os.File
+	// out writes to spill, through a redactingWriter for text bodies.
+	out      io.Writer
+	err      error
+	recorded bool
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.capture(p[:n])
+	if errors.Is(err, io.EOF) {
+		if recErr := b.record(); recErr != nil {
+			return n, recErr
+		}
+	}
+	return n, err
+}
+
+// Close records the rest of the body, so the cassette has the whole
+// response even if the caller stopped reading early.
+func (b *recordingBody) Close() error {
+	_, err := io.Copy(io.Discard, struct{ io.Reader }{b})
+	if closeErr := b.ReadCloser.Close(); err == nil {
+		err = closeErr
+	}
+	if b.spill != nil && !b.recorded {
+		b.spill.Close()
+		os.Remove(b.spill.Name())
+	}
+	return err
+}
+
+func (b *recordingBody) capture(p []byte) {
+	if b.err != nil || len(p) == 0 {
+		return
+	}
+	if b.spill == nil && len(b.inline)+len(p) <= maxInlineCassetteBody {
+		b.inline = append(b.inline, p...)
+		return
+	}
+	if b.spill == nil {
+		if b.err = b.startSpill(validUTF8Prefix(b.inline)); b.err != nil {
+			return
+		}
+	}
+	_, b.err = b.out.Write(p)
+}
+
+// startSpill moves the body read so far to a new body file, redacting what
+// is written to it if the body is text.
+func (b *recordingBody) startSpill(text bool) error {
+	dir := cassetteBodyDir(b.recorder.file)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, "body-*")
+	if err != nil {
+		return err
+	}
+	b.spill, b.out = f, f
+	if text {
+		b.out = &redactingWriter{w: f, secrets: b.secrets}
+	}
+	_, err = b.out.Write(b.inline)
+	b.inline = nil
+	return err
+}
+
+// record appends the interaction once the whole body has been read.
+func (b *recordingBody) record() error {
+	if b.recorded {
+		return nil
+	}
+	err := b.err
+	if err == nil && b.spill == nil && !utf8.Valid(b.inline) {
+		err = b.startSpill(false)
+	}
+	if w, ok := b.out.(*redactingWriter); ok && err == nil {
+		err = w.Flush()
+	}
+	if errors.Is(err, errUnredactable) {
+		// Record that there was a body rather than risk leaking the secret.
+		b.spill.Close()
+		os.Remove(b.spill.Name())
+		b.spill, err = nil, nil
+		b.interaction.Body = "[response body omitted from the cassette: " + errUnredactable.Error() + "]"
+	} else if err == nil && b.spill != nil {
+		if err = b.spill.Close(); err == nil {
+			rel, relErr := filepath.Rel(filepath.Dir(b.recorder.file), b.spill.Name())
+			b.interaction.BodyFile, err = filepath.ToSlash(rel), relErr
+		}
+	} else if err == nil {
+		b.interaction.Body = redactSecrets(string(b.inline), b.secrets...)
+	}
+	if err == nil {
+		err = b.recorder.append(b.interaction)
+	}
+	if err != nil {
+		return fmt.Errorf("record Seq API traffic to %s: %w", b.recorder.file, err)
+	}
+	b.recorded = true
+	return nil
+}
+
+// validUTF8Prefix reports whether b, the start of a body, is valid UTF-8,
+// ignoring a rune cut off at its end.
+func validUTF8Prefix(b []byte) bool {
+	i := len(b) - 1
+	for i > 0 && len(b)-i < utf8.UTFMax && !utf8.RuneStart(b[i]) {
+		i--
+	}
+	if i >= 0 && !utf8.FullRune(b[i:]) {
+		b = b[:i]
+	}
+	return utf8.Valid(b)
+}
+
+// redactingWriter redacts a text body in chunks as it is written to w. Each
+// chunk is cut where no secret or secret field spans the cut, and a secret
+// field whose value has not ended yet is held back until it does; if one
+// grows beyond maxInlineCassetteBody, Write fails with errUnredactable.
+type redactingWriter struct {
+	w       io.Writer
+	secrets []string
+
+	buf []byte
+	// next is the buffer length at which to look for a cut again, held
+	// whether the last cut stopped at an unended secret field, and scanned
+	// how much of buf had arrived then.
+	next    int
+	held    bool
+	scanned int
+}
+
+func (w *redactingWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	if len(w.buf) < max(w.next, redactChunk+redactOverlap) {
+		return len(p), nil
+	}
+	// A held back value can only end at a quote.
+	if !w.held || bytes.IndexByte(w.buf[w.scanned:], '"') >= 0 {
+		var cut int
+		cut, w.held = redactCut(string(w.buf), w.secrets, redactOverlap)
+		if err := w.flush(cut); err != nil {
+			return 0, err
+		}
+		w.next = len(w.buf) + redactChunk
+	}
+	w.scanned = len(w.buf)
+	if len(w.buf) > maxInlineCassetteBody {
+		return 0, errUnredactable
+	}
+	return len(p), nil
+}
+
+// Flush redacts and writes the rest of the body once it has all been
+// written.
+func (w *redactingWriter) Flush() error {
+	cut, held := redactCut(string(w.buf), w.secrets, 0)
+	if err := w.flush(cut); err != nil {
+		return err
+	}
+	if held {
+		return errUnredactable
+	}
+	return nil
+}
+
+func (w *redactingWriter) flush(cut int) error {
+	if cut == 0 {
+		return nil
+	}
+	if _, err := io.WriteString(w.w, redactSecrets(string(w.buf[:cut]), w.secrets...)); err != nil {
+		return err
+	}
+	w.buf = append(w.buf[:0], w.buf[cut:]...)
+	return nil
+}
+
+// redactCut returns how much of s, keeping at least overlap bytes back, can
+// be redacted on its own: the cut is moved past any secret or secret field
+// spanning it, and back to the start of a secret field whose value does not
+// end within s, reporting true.
+func redactCut(s string, secrets []string, overlap int) (int, bool) {
+	// A secret field's value ends at the next quote, so only one opening at
+	// the last quote can still be open.
+	if q := strings.LastIndexByte(s, '"'); q >= 0 {
+		lo := max(0, q+1-redactOverlap)
+		if m := secretFieldOpenPattern.FindStringIndex(s[lo : q+1]); m != nil && lo+m[0] < len(s)-overlap {
+			return lo + m[0], true
+		}
+	}
+
+	cut := len(s) - overlap
+	if cut <= 0 {
+		return 0, false
+	}
+	fields := secretFieldPattern.FindAllStringIndex(s, -1)
+	for moved := true; moved; {
+		moved = false
+		for _, m := range fields {
+			if m[0] < cut && m[1] > cut {
+				cut, moved = m[1], true
+			}
+		}
+		for _, secret := range secrets {
+			if len(secret) < 4 {
+				continue
+			}
+			lo, hi := max(0, cut-len(secret)+1), min(len(s), cut+len(secret)-1)
+			if i := strings.Index(s[lo:hi], secret); i >= 0 {
+				cut, moved = lo+i+len(secret), true
+			}
+		}
+	}
+	return cut, false
+}
+
+func (r *cassetteRecorder) append(interaction cassetteInteraction) error {
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, err := os.OpenFile(r.file, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func loadCassette(file string) ([]cassetteInteraction, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var interactions []cassetteInteraction
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, maxCassetteLine)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var interaction cassetteInteraction
+		if err := json.Unmarshal(scanner.Bytes(), &interaction); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", file, line, err)
+		}
+		interactions = append(interactions, interaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", file, err)
+	}
+	return interactions, nil
+}
+
+// cassetteReplayer answers requests from recorded interactions without
+// contacting Seq. Interactions with the same method and path are replayed in
+// recorded order; once they are used up the last one is repeated, since each
+// Terraform command starts a new provider process that repeats requests such
+// as reading the API root.
+type cassetteReplayer struct {
+	baseURL *url.URL
+	// dir is the cassette's directory, which body files are relative to.
+	dir string
+
+	mu           sync.Mutex
+	interactions []cassetteInteraction
+	used         []bool
+}
+
+func (r *cassetteReplayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	path := cassettePath(r.baseURL, req.URL)
+
+	r.mu.Lock()
+	match := -1
+	for i, interaction := range r.interactions {
+		if interaction.Method != req.Method || interaction.Path != path {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match >= 0 {
+		r.used[match] = true
+	}
+	r.mu.Unlock()
+
+	if match < 0 {
+		return nil, fmt.Errorf("the replayed cassette has no response for %s %s", req.Method, path)
+	}
+
+	interaction := r.interactions[match]
+	var body io.ReadCloser = io.NopCloser(strings.NewReader(interaction.Body))
+	length := int64(len(interaction.Body))
+	if interaction.BodyFile != "" {
+		f, err := os.Open(filepath.Join(r.dir, filepath.FromSlash(interaction.BodyFile)))
+		if err != nil {
+			return nil, fmt.Errorf("the replayed cassette's response for %s %s: %w", req.Method, path, err)
+		}
+		info, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		body, length = f, info.Size()
+	}
+	header := http.Header{}
+	if interaction.ContentType != "" {
+		header.Set("Content-Type", interaction.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+		StatusCode:    interaction.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          body,
+		ContentLength: length,
+		Request:       req,
+	}, nil
+}
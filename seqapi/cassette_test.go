@@ -0,0 +1,222 @@
+package seqapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCassetteReplayOrder(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "seq.jsonl")
+	lines := `{"method":"GET","path":"/api/apikeys/apikey-1","status":200,"body":"{\"Title\":\"first\"}"}
+{"method":"GET","path":"/api/apikeys/apikey-1","status":200,"body":"{\"Title\":\"second\"}"}
+`
+	if err := os.WriteFile(cassette, []byte(lines), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := New(Config{ServerURL: "http://seq.invalid/prefix/", ReplayCassette: cassette})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"first", "second", "second"} {
+		got, err := c.GetAPIKey(context.Background(), "apikey-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Title != want {
+			t.Fatalf("title = %q, want %q", got.Title, want)
+		}
+	}
+	if err := c.DoJSON(context.Background(), http.MethodGet, "/api/permalinks", nil, nil); err == nil {
+		t.Fatal("expected an error for a request missing from the cassette")
+	}
+}
+
+func TestCassetteRecordsLargeAndBinaryBodiesToFiles(t *testing.T) {
+	backup := []byte{0x1f, 0x8b, 0x08, 0x00, 0xff, 0xfe, 0x00, 0x01}
+	events := `[{"Id": "` + strings.Repeat("e", maxInlineCassetteBody) + `"}]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/backups/backup-1/download":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(backup)
+		case "/api/events":
+			_, _ = w.Write([]byte(events))
+		default:
+			_, _ = w.Write([]byte(`{"Title": "small"}`))
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	cassette := filepath.Join(t.TempDir(), "seq.jsonl")
+	c, err := New(Config{ServerURL: srv.URL, RecordCassette: cassette})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := c.DownloadBackup(ctx, "backup-1", &buf); err != nil {
+		t.Fatal(err)
+	}
+	var listed []pagedItem
+	for item, err := range ListItems[pagedItem](ctx, c, "/api/events") {
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed = append(listed, item)
+	}
+	if _, err := c.GetAPIKey(ctx, "apikey-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	recorded, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recorded) > 4096 {
+		t.Fatalf("expected large and binary bodies outside the cassette, got %d bytes", len(recorded))
+	}
+	var bodyFiles int
+	for _, line := range strings.Split(strings.TrimSpace(string(recorded)), "\n") {
+		var interaction cassetteInteraction
+		if err := json.Unmarshal([]byte(line), &interaction); err != nil {
+			t.Fatal(err)
+		}
+		if interaction.BodyFile != "" {
+			bodyFiles++
+			if !strings.HasPrefix(interaction.BodyFile, "seq.jsonl.bodies/") {
+				t.Fatalf("unexpected body file %q", interaction.BodyFile)
+			}
+		} else if interaction.Body != `{"Title": "small"}` {
+			t.Fatalf("unexpected inline body %q", interaction.Body)
+		}
+	}
+	if bodyFiles != 2 {
+		t.Fatalf("expected 2 body files, got %d:\n%s", bodyFiles, recorded)
+	}
+
+	// Replay from another directory's copy to check body files are found
+	// relative to the cassette.
+	moved := filepath.Join(t.TempDir(), "seq.jsonl")
+	if err := os.Rename(cassette, moved); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(cassetteBodyDir(cassette), cassetteBodyDir(moved)); err != nil {
+		t.Fatal(err)
+	}
+	c, err = New(Config{ServerURL: "http://seq.invalid", ReplayCassette: moved})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if _, err := c.DownloadBackup(ctx, "backup-1", &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), backup) {
+		t.Fatalf("replayed backup = %x, want %x", buf.Bytes(), backup)
+	}
+	var replayed []pagedItem
+	for item, err := range ListItems[pagedItem](ctx, c, "/api/events") {
+		if err != nil {
+			t.Fatal(err)
+		}
+		replayed = append(replayed, item)
+	}
+	if len(replayed) != 1 || replayed[0].ID != listed[0].ID {
+		t.Fatalf("unexpected replayed events")
+	}
+}
+
+func TestCassetteRedactsLargeTextBodies(t *testing.T) {
+	const apiKey = "key-Zq81bXr"
+	// Start with a secret spanning several chunks, then vary the item length
+	// so secrets fall at every offset around the chunk boundaries.
+	var events strings.Builder
+	events.WriteString(`[{"Id": "event-long", "Token": "` + strings.Repeat("tok-Pv7d", 3*redactChunk/8) + `"}`)
+	for i := 0; events.Len() <= maxInlineCassetteBody+2*redactChunk; i++ {
+		events.WriteString(",")
+		fmt.Fprintf(&events, `{"Id": "event-%d", "Data": %q, "Token": "tok-Pv7d", "Message": "auth with `+apiKey+` and {\"Password\": \"pw-Hx3\"}"}`, i, strings.Repeat("x", i%997))
+	}
+	events.WriteString("]")
+	unterminated := `[{"Id": "event-1", "Secret": "` + strings.Repeat("s", maxInlineCassetteBody+redactChunk)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/events":
+			_, _ = w.Write([]byte(events.String()))
+		default:
+			_, _ = w.Write([]byte(unterminated))
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	cassette := filepath.Join(t.TempDir(), "seq.jsonl")
+	c, err := New(Config{ServerURL: srv.URL, APIKey: apiKey, RecordCassette: cassette})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var listed int
+	for _, err := range ListItems[pagedItem](ctx, c, "/api/events") {
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed++
+	}
+	for range ListItems[pagedItem](ctx, c, "/api/unterminated") {
+	}
+
+	recorded, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(recorded)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 interactions, got %d", len(lines))
+	}
+	var interaction cassetteInteraction
+	if err := json.Unmarshal([]byte(lines[0]), &interaction); err != nil {
+		t.Fatal(err)
+	}
+	if interaction.BodyFile == "" {
+		t.Fatalf("expected the events in a body file, got %q", interaction.Body)
+	}
+	body, err := os.ReadFile(filepath.Join(filepath.Dir(cassette), interaction.BodyFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{apiKey, "tok-Pv7d", "pw-Hx3"} {
+		if bytes.Contains(body, []byte(secret)) {
+			t.Fatalf("body file contains %q", secret)
+		}
+	}
+	var redacted []map[string]string
+	if err := json.Unmarshal(body, &redacted); err != nil {
+		t.Fatalf("redacted body is not JSON: %v", err)
+	}
+	if len(redacted) != listed || redacted[0]["Token"] != "***" || redacted[listed-1]["Token"] != "***" {
+		t.Fatalf("unexpected redacted events: %d of %d, last %v", len(redacted), listed, redacted[len(redacted)-1])
+	}
+
+	interaction = cassetteInteraction{}
+	if err := json.Unmarshal([]byte(lines[1]), &interaction); err != nil {
+		t.Fatal(err)
+	}
+	if interaction.BodyFile != "" || !strings.Contains(interaction.Body, "omitted") {
+		t.Fatalf("expected the unterminated secret to be omitted, got %+v", interaction)
+	}
+	files, err := os.ReadDir(cassetteBodyDir(cassette))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected only the events body file, got %d files", len(files))
+	}
+}
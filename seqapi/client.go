@@ -0,0 +1,390 @@
+// Package seqapi is a client for the Seq HTTP API.
+//
+// Client handles authentication, error decoding, secret redaction in logs and
+// errors, client-side request limits, an optional read cache and
+// record/replay cassettes. It adapts request shapes to the server's Seq
+// version (see DetectCapabilities). Entities used by the Terraform provider
+// have typed methods such as ListAPIKeys and CreateAPIKey; other endpoints
+// can be called with DoJSON, ListItems and ListPages.
+//
+// Ref: https://datalust.co/docs/server-http-api
+package seqapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultTimeout is the request timeout used when Config.Timeout is zero.
+const DefaultTimeout = 30 * time.Second
+
+// Config configures a Client.
+type Config struct {
+	// ServerURL is the base URL of the Seq server, e.g.
+	// https://seq.example.com or http://localhost:5342.
+	ServerURL string
+	// APIKey is sent in the X-Seq-ApiKey header when set.
+	APIKey string
+	// Timeout bounds each request, including reading its response, unless
+	// its context comes from WithoutTimeout. Zero means DefaultTimeout.
+	Timeout time.Duration
+	// InsecureSkipVerify disables TLS certificate verification.
+	InsecureSkipVerify bool
+	// HTTPClient, if set, is used instead of a client built from Timeout
+	// and InsecureSkipVerify.
+	HTTPClient *http.Client
+
+	// MaxConcurrentRequests limits the requests in flight at once and
+	// RequestsPerSecond how quickly new ones start; zero means unlimited.
+	MaxConcurrentRequests int64
+	RequestsPerSecond     float64
+	// CacheReads serves GetEntity (and the typed Get methods) from one
+	// listing of each collection; writes invalidate the affected listing.
+	CacheReads bool
+
+	// RecordCassette appends every request and response, with secrets
+	// redacted, to this file; binary and large response bodies go to files
+	// in a directory beside it, and only binary ones are left unredacted.
+	// ReplayCassette serves responses from such a file instead of
+	// contacting Seq. At most one may be set.
+	RecordCassette string
+	ReplayCassette string
+}
+
+// Client is a minimal HTTP client for talking to the Seq HTTP API. It is safe
+// for concurrent use.
+//
+// Authentication uses the X-Seq-ApiKey header (recommended by Seq).
+// Ref: https://datalust.co/docs/using-the-http-api
+type Client struct {
+	baseURL *url.URL
+	apiKey  string
+	http    *http.Client
+	caps    serverCapabilities
+	limiter *requestLimiter
+	cache   *collectionCache
+}
+
+// New returns a client for cfg. It does not contact the server; call
+// DetectCapabilities to adapt to the server's Seq version.
+func New(cfg Config) (*Client, error) {
+	baseURL, err := url.Parse(cfg.ServerURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server URL: %w", err)
+	}
+	if baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, errors.New("server URL must include scheme and host, e.g. http://localhost:5342")
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		timeout := cfg.Timeout
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+		httpClient = &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify},
+			},
+		}
+	}
+
+	if cfg.RecordCassette != "" || cfg.ReplayCassette != "" {
+		transport, err := cassetteTransport(httpClient.Transport, baseURL, cfg.RecordCassette, cfg.ReplayCassette)
+		if err != nil {
+			return nil, err
+		}
+		httpClient = &http.Client{
+			Transport:     transport,
+			CheckRedirect: httpClient.CheckRedirect,
+			Jar:           httpClient.Jar,
+			Timeout:       httpClient.Timeout,
+		}
+	}
+
+	c := &Client{
+		baseURL: baseURL,
+		apiKey:  cfg.APIKey,
+		http:    httpClient,
+		limiter: newRequestLimiter(cfg.MaxConcurrentRequests, cfg.RequestsPerSecond),
+	}
+	if cfg.CacheReads {
+		c.cache = newCollectionCache()
+	}
+	return c, nil
+}
+
+type withoutTimeoutKey struct{}
+
+// WithoutTimeout returns a context whose requests are bounded only by ctx,
+// not by Config.Timeout. Use it, with a deadline, for calls that may take
+// longer than the client's timeout, such as long-running SQL queries.
+func WithoutTimeout(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutTimeoutKey{}, true)
+}
+
+// ServerURL returns the server URL without a trailing slash.
+func (c *Client) ServerURL() string {
+	return strings.TrimSuffix(c.baseURL.String(), "/")
+}
+
+// APIKey returns the API key the client authenticates with, if any.
+func (c *Client) APIKey() string {
+	return c.apiKey
+}
+
+// Ping checks that the server is up via /health.
+func (c *Client) Ping(ctx context.Context) error {
+	var out map[string]any
+	return c.DoJSON(ctx, http.MethodGet, "/health", nil, &out)
+}
+
+// strictExpressionResponse is returned by /api/expressions/to-strict.
+type strictExpressionResponse struct {
+	StrictExpression      string `json:"StrictExpression"`
+	MatchedAsText         bool   `json:"MatchedAsText"`
+	ReasonIfMatchedAsText string `json:"ReasonIfMatchedAsText"`
+}
+
+// ToStrictExpression asks Seq to convert a (possibly non-strict) filter
+// expression into strict syntax.
+func (c *Client) ToStrictExpression(ctx context.Context, fuzzy string) (string, error) {
+	var out strictExpressionResponse
+	path := "/api/expressions/to-strict?" + url.Values{"fuzzy": {fuzzy}}.Encode()
+	if err := c.DoJSON(ctx, http.MethodGet, path, nil, &out); err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(out.StrictExpression) == "" {
+		return "", fmt.Errorf("seq returned an empty strict expression for filter %q", fuzzy)
+	}
+	return out.StrictExpression, nil
+}
+
+// DoJSON performs an HTTP request with a JSON body (if body is non-nil) and
+// decodes the JSON response into out (if non-nil). Non-2xx responses are
+// returned as *HTTPError.
+func (c *Client) DoJSON(ctx context.Context, method, path string, body any, out any) error {
+	var bodyReader io.Reader
+	contentType := ""
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		bodyReader = bytes.NewReader(b)
+		contentType = "application/json"
+	}
+
+	return c.do(ctx, method, path, contentType, bodyReader, "", out)
+}
+
+// do performs an HTTP request and decodes a JSON response into out (if
+// non-nil). apiKey overrides the client's API key when non-empty.
+func (c *Client) do(ctx context.Context, method, path, contentType string, body io.Reader, apiKey string, out any) error {
+	resp, err := c.send(ctx, method, path, contentType, body, apiKey)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode straight from the response, keeping only a capped copy of the
+	// body for the trace log.
+	logged := &cappedBuffer{limit: maxLoggedBodyBytes + 1}
+	data := io.TeeReader(resp.Body, logged)
+	defer func() {
+		logResponseBody(resp.Request.Context(), resp, logged.Bytes(), requestSecrets(c.apiKey, apiKey))
+	}()
+
+	if out != nil {
+		if err := json.NewDecoder(data).Decode(out); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("decode JSON response: %w", err)
+		}
+	}
+	_, err = io.Copy(io.Discard, data)
+	return err
+}
+
+// Download performs a GET request and streams the response body to w,
+// returning the number of bytes written.
+//
+// Downloads such as backups can take far longer than Config.Timeout, so it
+// does not bound the whole download: the download fails only if no data
+// arrives for that long, or when ctx ends.
+func (c *Client) Download(ctx context.Context, path string, w io.Writer) (int64, error) {
+	ctx, cancel := context.WithCancelCause(WithoutTimeout(ctx))
+	defer cancel(nil)
+
+	idle := c.http.Timeout
+	progress := func() {}
+	if idle > 0 {
+		stalled := time.AfterFunc(idle, func() {
+			cancel(fmt.Errorf("download stalled: no data received for %s", idle))
+		})
+		defer stalled.Stop()
+		progress = func() { stalled.Reset(idle) }
+	}
+
+	resp, err := c.send(ctx, http.MethodGet, path, "", nil, "")
+	if err != nil {
+		return 0, downloadError(ctx, err)
+	}
+	defer resp.Body.Close()
+
+	n, err := io.Copy(w, &progressReader{Reader: resp.Body, progress: progress})
+	return n, downloadError(ctx, err)
+}
+
+// downloadError reports why a download's context was cancelled, e.g. a
+// stall, in place of the bare cancellation error.
+func downloadError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	if cause := context.Cause(ctx); cause != nil && !errors.Is(err, cause) {
+		return fmt.Errorf("%w: %w", cause, err)
+	}
+	return err
+}
+
+// progressReader calls progress after every read that returns data.
+type progressReader struct {
+	io.Reader
+	progress func()
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.progress()
+	}
+	return n, err
+}
+
+// send performs an HTTP request and returns the response if it has a 2xx
+// status code; other responses are returned as *HTTPError. The caller must
+// close the response body.
+func (c *Client) send(ctx context.Context, method, path, contentType string, body io.Reader, apiKey string) (*http.Response, error) {
+	fullURL, err := c.baseURL.Parse(strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := requestSecrets(c.apiKey, apiKey)
+	ctx = httpLogContext(ctx, secrets...)
+
+	if method != http.MethodGet && method != http.MethodHead {
+		// Invalidate once the write has completed, whatever its outcome, so
+		// a listing read while it was in flight does not stay cached.
+		defer c.cache.invalidate(path)
+	}
+
+	// Buffer the body so it can be logged; request bodies are small.
+	var bodyBytes []byte
+	if body != nil {
+		if bodyBytes, err = io.ReadAll(body); err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(bodyBytes)
+	}
+
+	// The span covers the whole exchange, including reading the response
+	// body, so it ends when the body is closed.
+	ctx, span := otel.Tracer(tracerName).Start(ctx, method+" "+spanRoute(path), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("http.request.method", method),
+		attribute.String("url.path", fullURL.Path),
+		attribute.String("server.address", fullURL.Hostname()),
+	))
+	endSpan := func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, redactSecrets(err.Error(), secrets...))
+		}
+		span.End()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL.String(), body)
+	if err != nil {
+		endSpan(err)
+		return nil, err
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if apiKey == "" {
+		apiKey = c.apiKey
+	}
+	if apiKey != "" {
+		req.Header.Set("X-Seq-ApiKey", apiKey)
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	release, queued, err := c.limiter.acquire(ctx)
+	if err != nil {
+		endSpan(err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int64("seq.request.queued_ms", queued.Milliseconds()))
+	if queued >= time.Millisecond {
+		tflog.SubsystemDebug(ctx, httpLogSubsystem, "Seq API request was queued by the client-side limits", map[string]any{
+			"method":    method,
+			"path":      req.URL.Path,
+			"queued_ms": queued.Milliseconds(),
+		})
+	}
+
+	httpClient := c.http
+	if ctx.Value(withoutTimeoutKey{}) != nil && httpClient.Timeout != 0 {
+		untimed := *httpClient
+		untimed.Timeout = 0
+		httpClient = &untimed
+	}
+
+	logRequest(ctx, req, bodyBytes, secrets)
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	logResponse(ctx, req, resp, err, start, queued, secrets)
+	if err != nil {
+		release()
+		endSpan(err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: func() {
+		release()
+		span.End()
+	}}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		logResponseBody(ctx, resp, data, secrets)
+		httpErr := newHTTPError(resp.StatusCode, resp.Status, data, secrets...)
+		span.SetStatus(codes.Error, httpErr.Message)
+		return nil, httpErr
+	}
+
+	return resp, nil
+}
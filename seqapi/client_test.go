@@ -0,0 +1,123 @@
+package seqapi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustParseURL(raw string) *url.URL {
+	u, err := url.Parse(raw)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func TestNewValidatesServerURL(t *testing.T) {
+	for _, raw := range []string{"localhost:5342", "/api", "://bad"} {
+		if _, err := New(Config{ServerURL: raw}); err == nil {
+			t.Errorf("New(%q) succeeded, want an error", raw)
+		}
+	}
+
+	c, err := New(Config{ServerURL: "https://seq.example.com/", APIKey: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ServerURL() != "https://seq.example.com" || c.APIKey() != "secret" {
+		t.Fatalf("ServerURL() = %q, APIKey() = %q", c.ServerURL(), c.APIKey())
+	}
+	if c.http.Timeout != DefaultTimeout {
+		t.Fatalf("timeout = %v, want %v", c.http.Timeout, DefaultTimeout)
+	}
+}
+
+func TestNewRejectsRecordAndReplay(t *testing.T) {
+	_, err := New(Config{ServerURL: "http://seq.invalid", RecordCassette: "a.jsonl", ReplayCassette: "b.jsonl"})
+	if err == nil || !strings.Contains(err.Error(), "recorded and replayed") {
+		t.Fatalf("expected an error when recording and replaying, got %v", err)
+	}
+}
+
+func TestWithoutTimeoutExemptsRequestsFromClientTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c, err := New(Config{ServerURL: srv.URL, Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]any
+	if err := c.DoJSON(context.Background(), http.MethodGet, "/api/data", nil, &out); err == nil {
+		t.Fatal("expected the client timeout to abandon the request")
+	}
+	if err := c.DoJSON(WithoutTimeout(context.Background()), http.MethodGet, "/api/data", nil, &out); err != nil {
+		t.Fatalf("request without timeout failed: %v", err)
+	}
+	if c.http.Timeout != 50*time.Millisecond {
+		t.Fatalf("client timeout changed to %v", c.http.Timeout)
+	}
+
+	ctx, cancel := context.WithTimeout(WithoutTimeout(context.Background()), 50*time.Millisecond)
+	defer cancel()
+	if err := c.DoJSON(ctx, http.MethodGet, "/api/data", nil, &out); err == nil {
+		t.Fatal("expected the context deadline to abandon the request")
+	}
+}
+
+func TestDownloadOutlastsClientTimeoutWhileDataArrives(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 5; i++ {
+			_, _ = w.Write([]byte("chunk"))
+			w.(http.Flusher).Flush()
+			time.Sleep(40 * time.Millisecond)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := New(Config{ServerURL: srv.URL, Timeout: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	n, err := c.Download(context.Background(), "/api/backups/backup-1/download", &buf)
+	if err != nil {
+		t.Fatalf("slow download failed: %v", err)
+	}
+	if n != 25 || buf.String() != strings.Repeat("chunk", 5) {
+		t.Fatalf("downloaded %d bytes %q", n, buf.String())
+	}
+}
+
+func TestDownloadFailsWhenBodyStalls(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("chunk"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c, err := New(Config{ServerURL: srv.URL, Timeout: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.Download(context.Background(), "/api/backups/backup-1/download", io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "download stalled: no data received for 100ms") {
+		t.Fatalf("expected a stall error, got %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package seqapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// HTTPError wraps non-2xx responses. Seq's JSON error envelope is decoded
+// into Message, Reasons and FieldErrors; Body keeps the (redacted) raw
+// response for endpoints with richer error documents.
+type HTTPError struct {
+	StatusCode int
+	Message    string
+	// Reasons lists additional explanations Seq gave for the error.
+	Reasons []string
+	// FieldErrors maps request property names (e.g. "Title" or
+	// "InputSettings.Filter") to validation messages.
+	FieldErrors map[string][]string
+	Body        string
+}
+
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("seq api returned %d: %s", e.StatusCode, e.Message)
+	for _, reason := range e.Reasons {
+		if reason != e.Message {
+			msg += "\n" + reason
+		}
+	}
+	for _, field := range e.FieldNames() {
+		for _, fieldMsg := range e.FieldErrors[field] {
+			msg += fmt.Sprintf("\n%s: %s", field, fieldMsg)
+		}
+	}
+	return msg
+}
+
+// FieldNames returns the properties with validation errors, sorted.
+func (e *HTTPError) FieldNames() []string {
+	names := make([]string, 0, len(e.FieldErrors))
+	for name := range e.FieldErrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsNotFound reports whether err is a Seq 404 response.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsForbidden reports whether err is a Seq 403 response.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsConflict reports whether err is a Seq 409 response.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+func hasStatus(err error, status int) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == status
+}
+
+// errorEnvelope is the JSON document Seq returns with error responses. Model
+// validation failures use the ASP.NET problem details shape instead, with
+// messages keyed by property in errors.
+type errorEnvelope struct {
+	Error   string              `json:"Error"`
+	Reasons []string            `json:"Reasons"`
+	Title   string              `json:"title"`
+	Errors  map[string][]string `json:"errors"`
+}
+
+// newHTTPError builds an HTTPError from a non-2xx response body, redacting
+// secrets before anything is stored.
+func newHTTPError(statusCode int, status string, body []byte, secrets ...string) *HTTPError {
+	raw := strings.TrimSpace(string(body))
+	redacted := redactSecrets(raw, secrets...)
+	httpErr := &HTTPError{StatusCode: statusCode, Message: redacted, Body: redacted}
+	if raw == "" {
+		httpErr.Message = status
+		return httpErr
+	}
+
+	var envelope errorEnvelope
+	if err := json.Unmarshal([]byte(raw), &envelope); err != nil {
+		return httpErr
+	}
+
+	switch {
+	case envelope.Error != "":
+		httpErr.Message = redactSecrets(envelope.Error, secrets...)
+	case envelope.Title != "":
+		httpErr.Message = redactSecrets(envelope.Title, secrets...)
+	case len(envelope.Errors) == 0:
+		// Some other JSON document; keep the raw body.
+		return httpErr
+	default:
+		httpErr.Message = status
+	}
+	for _, reason := range envelope.Reasons {
+		httpErr.Reasons = append(httpErr.Reasons, redactSecrets(reason, secrets...))
+	}
+	if len(envelope.Errors) > 0 {
+		httpErr.FieldErrors = make(map[string][]string, len(envelope.Errors))
+		for field, msgs := range envelope.Errors {
+			redacted := make([]string, 0, len(msgs))
+			for _, msg := range msgs {
+				redacted = append(redacted, redactSecrets(msg, secrets...))
+			}
+			httpErr.FieldErrors[strings.TrimPrefix(field, "$.")] = redacted
+		}
+	}
+	return httpErr
+}
+
+// secretFieldStart matches the name of a JSON token, password or secret field
+// and the opening quote of its value, including JSON embedded (escaped) in
+// another JSON string.
+const secretFieldStart = `(?i)(\\?"(?:[a-z]*token|[a-z]*password|apikey|[a-z]*secret)\\?"\s*:\s*)(\\?)"`
+
+// secretFieldPattern matches JSON token, password and secret fields with
+// their values.
+var secretFieldPattern = regexp.MustCompile(secretFieldStart + `(?:[^"\\]|\\[^"])*\\?"`)
+
+// secretFieldOpenPattern matches a secret field whose value begins at the
+// end of the text, so a streamed body can hold back a value that has not
+// ended yet.
+var secretFieldOpenPattern = regexp.MustCompile(secretFieldStart + `$`)
+
+// redactSecrets masks the given secret values and any JSON token, password or
+// secret fields in s, since Seq may echo request bodies back in errors.
+func redactSecrets(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if len(secret) >= 4 {
+			s = strings.ReplaceAll(s, secret, "***")
+		}
+	}
+	return secretFieldPattern.ReplaceAllString(s, `${1}${2}"***${2}"`)
+}
@@ -0,0 +1,102 @@
+package seqapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewHTTPErrorDecodesSeqEnvelope(t *testing.T) {
+	err := newHTTPError(http.StatusBadRequest, "400 Bad Request",
+		[]byte(`{"Error": "The API key title must be unique.", "Reasons": ["A key titled 'ingest' already exists."]}`))
+
+	if err.Message != "The API key title must be unique." {
+		t.Fatalf("unexpected message %q", err.Message)
+	}
+	want := "seq api returned 400: The API key title must be unique.\nA key titled 'ingest' already exists."
+	if err.Error() != want {
+		t.Fatalf("unexpected error text %q", err.Error())
+	}
+}
+
+func TestNewHTTPErrorKeepsNonEnvelopeBodies(t *testing.T) {
+	if err := newHTTPError(http.StatusBadGateway, "502 Bad Gateway", []byte("upstream unavailable")); err.Message != "upstream unavailable" {
+		t.Fatalf("unexpected message %q", err.Message)
+	}
+	if err := newHTTPError(http.StatusNotFound, "404 Not Found", nil); err.Message != "404 Not Found" {
+		t.Fatalf("unexpected message %q", err.Message)
+	}
+	if err := newHTTPError(http.StatusBadRequest, "400 Bad Request", []byte(`{"Columns": []}`)); err.Message != `{"Columns": []}` {
+		t.Fatalf("unexpected message %q", err.Message)
+	}
+}
+
+func TestNewHTTPErrorRedactsSecrets(t *testing.T) {
+	body := `{"Error": "Invalid request using key abcd1234efgh", "Reasons": ["{\"Title\":\"x\",\"Token\":\"s3cr3t-token\",\"NewPassword\":\"hunter2\",\"TokenPrefix\":\"abc\"}"]}`
+	err := newHTTPError(http.StatusBadRequest, "400 Bad Request", []byte(body), "abcd1234efgh")
+
+	if err.Message != "Invalid request using key ***" {
+		t.Fatalf("unexpected message %q", err.Message)
+	}
+	if !json.Valid([]byte(err.Body)) {
+		t.Fatalf("expected redacted body to remain valid JSON, got %q", err.Body)
+	}
+
+	text := err.Error() + err.Body
+	for _, secret := range []string{"abcd1234efgh", "s3cr3t-token", "hunter2"} {
+		if strings.Contains(text, secret) {
+			t.Fatalf("expected %q to be redacted from %q", secret, text)
+		}
+	}
+	if !strings.Contains(text, `TokenPrefix\":\"abc`) {
+		t.Fatalf("expected non-secret fields to be kept, got %q", text)
+	}
+}
+
+func TestStatusHelpers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var status int
+		fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/"), "%d", &status)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	c := &Client{baseURL: mustParseURL(srv.URL), http: srv.Client()}
+	get := func(status int) error {
+		return c.DoJSON(context.Background(), http.MethodGet, fmt.Sprintf("/%d", status), nil, nil)
+	}
+
+	if err := get(http.StatusNotFound); !IsNotFound(err) || IsForbidden(err) || IsConflict(err) {
+		t.Fatalf("expected only IsNotFound for %v", err)
+	}
+	if err := get(http.StatusForbidden); !IsForbidden(err) {
+		t.Fatalf("expected IsForbidden for %v", err)
+	}
+	if err := fmt.Errorf("wrapped: %w", get(http.StatusConflict)); !IsConflict(err) {
+		t.Fatalf("expected IsConflict for %v", err)
+	}
+	if IsNotFound(nil) || IsNotFound(fmt.Errorf("not an HTTP error")) {
+		t.Fatalf("expected non-HTTP errors not to match")
+	}
+}
+
+func TestNewHTTPErrorDecodesProblemDetails(t *testing.T) {
+	err := newHTTPError(http.StatusBadRequest, "400 Bad Request", []byte(`{
+		"title": "One or more validation errors occurred.",
+		"errors": {"$.Title": ["The Title field is required."], "inputSettings.minimumLevel": ["Unknown level 'Loud'."]}
+	}`))
+
+	if err.Message != "One or more validation errors occurred." {
+		t.Fatalf("unexpected message %q", err.Message)
+	}
+	if got := err.FieldNames(); len(got) != 2 || got[0] != "Title" || got[1] != "inputSettings.minimumLevel" {
+		t.Fatalf("unexpected field names %v", got)
+	}
+	if !strings.Contains(err.Error(), "inputSettings.minimumLevel: Unknown level 'Loud'.") {
+		t.Fatalf("expected field errors in the message, got %q", err.Error())
+	}
+}
@@ -0,0 +1,127 @@
+package seqapi
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// httpLogSubsystem is the tflog subsystem for Seq HTTP traffic. Its level can
+// be set separately with TF_LOG_PROVIDER_SEQ_HTTP, e.g. to TRACE to include
+// request and response bodies.
+const httpLogSubsystem = "http"
+
+// maxLoggedBodyBytes caps logged request and response bodies.
+const maxLoggedBodyBytes = 64 * 1024
+
+// sensitiveHeaders are masked wherever headers are logged.
+var sensitiveHeaders = []string{"X-Seq-ApiKey", "Authorization", "Cookie", "Set-Cookie"}
+
+// httpLogContext returns ctx with the HTTP logging subsystem configured to
+// mask the given secrets in messages and fields.
+func httpLogContext(ctx context.Context, secrets ...string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, httpLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_SEQ", "HTTP"))
+
+	var masked []string
+	for _, secret := range secrets {
+		if len(secret) >= 4 {
+			masked = append(masked, secret)
+		}
+	}
+	if len(masked) > 0 {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, httpLogSubsystem, masked...)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, httpLogSubsystem, masked...)
+	}
+	return ctx
+}
+
+// logRequest logs an outgoing request. Headers and the body are only logged
+// at TRACE, with secrets redacted.
+func logRequest(ctx context.Context, req *http.Request, body []byte, secrets []string) {
+	fields := map[string]any{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	}
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, "Sending Seq API request", fields)
+	tflog.SubsystemTrace(ctx, httpLogSubsystem, "Seq API request details", map[string]any{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"headers": redactHeaders(req.Header),
+		"body":    loggedBody(body, secrets),
+	})
+}
+
+// logResponse logs the outcome of a request (status or transport error), its
+// duration and how long it was queued by the client-side limits.
+func logResponse(ctx context.Context, req *http.Request, resp *http.Response, err error, start time.Time, queued time.Duration, secrets []string) {
+	fields := map[string]any{
+		"method":      req.Method,
+		"path":        req.URL.Path,
+		"duration_ms": time.Since(start).Milliseconds(),
+		"queued_ms":   queued.Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = redactSecrets(err.Error(), secrets...)
+		tflog.SubsystemDebug(ctx, httpLogSubsystem, "Seq API request failed", fields)
+		return
+	}
+	fields["status"] = resp.StatusCode
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, "Received Seq API response", fields)
+}
+
+// logResponseBody logs response headers and body at TRACE, with secrets
+// redacted. Streamed downloads are not logged.
+func logResponseBody(ctx context.Context, resp *http.Response, body []byte, secrets []string) {
+	tflog.SubsystemTrace(ctx, httpLogSubsystem, "Seq API response details", map[string]any{
+		"method":  resp.Request.Method,
+		"path":    resp.Request.URL.Path,
+		"status":  resp.StatusCode,
+		"headers": redactHeaders(resp.Header),
+		"body":    loggedBody(body, secrets),
+	})
+}
+
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for name, values := range h {
+		value := strings.Join(values, ", ")
+		for _, sensitive := range sensitiveHeaders {
+			if strings.EqualFold(name, sensitive) {
+				value = "***"
+				break
+			}
+		}
+		out[name] = value
+	}
+	return out
+}
+
+func loggedBody(body []byte, secrets []string) string {
+	truncated := false
+	if len(body) > maxLoggedBodyBytes {
+		body = body[:maxLoggedBodyBytes]
+		truncated = true
+	}
+	s := redactSecrets(string(body), secrets...)
+	if truncated {
+		s += "... (truncated)"
+	}
+	return s
+}
+
+// requestSecrets returns the non-empty API keys a request may carry, sorted
+// longest first so overlapping values are fully masked.
+func requestSecrets(keys ...string) []string {
+	var secrets []string
+	for _, key := range keys {
+		if key != "" {
+			secrets = append(secrets, key)
+		}
+	}
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	return secrets
+}
@@ -0,0 +1,62 @@
+package seqapi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestClientLogsRedactedHTTPTraffic(t *testing.T) {
+	const apiKey = "super-secret-api-key"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Id": "apikey-1", "Title": "ingest", "Token": "new-token-value", "TokenPrefix": "new"}`))
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+
+	c := &Client{baseURL: mustParseURL(srv.URL), apiKey: apiKey, http: srv.Client()}
+	body := map[string]any{"Title": "ingest", "NewPassword": "hunter2-password"}
+	if err := c.DoJSON(ctx, http.MethodPost, "/api/apikeys", body, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logs := out.String()
+	entries, err := tflogtest.MultilineJSONDecode(&out)
+	if err != nil {
+		t.Fatalf("decode logs: %v", err)
+	}
+
+	var sawStatus, sawRequestBody, sawResponseBody bool
+	for _, entry := range entries {
+		if entry["@module"] != "provider."+httpLogSubsystem {
+			t.Fatalf("expected entries in the %s subsystem, got %v", httpLogSubsystem, entry)
+		}
+		if entry["@message"] == "Received Seq API response" {
+			sawStatus = entry["status"] == float64(200) && entry["path"] == "/api/apikeys" && entry["duration_ms"] != nil
+		}
+		if entry["@message"] == "Seq API request details" {
+			headers := entry["headers"].(map[string]any)
+			sawRequestBody = headers["X-Seq-Apikey"] == "***" && strings.Contains(entry["body"].(string), `"Title":"ingest"`)
+		}
+		if entry["@message"] == "Seq API response details" {
+			sawResponseBody = strings.Contains(entry["body"].(string), `"TokenPrefix": "new"`)
+		}
+	}
+	if !sawStatus || !sawRequestBody || !sawResponseBody {
+		t.Fatalf("missing expected log entries (status=%v request=%v response=%v): %v", sawStatus, sawRequestBody, sawResponseBody, entries)
+	}
+
+	for _, secret := range []string{apiKey, "hunter2-password", "new-token-value"} {
+		if strings.Contains(logs, secret) {
+			t.Fatalf("expected %q to be masked in logs", secret)
+		}
+	}
+}
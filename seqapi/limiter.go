@@ -0,0 +1,83 @@
+package seqapi
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// requestLimiter bounds how many requests a Client has in flight and how
+// quickly it starts new ones. It is shared by every caller of the Client,
+// such as the provider's resources, data sources and actions, so Terraform's
+// -parallelism cannot overwhelm a small Seq instance. A nil *requestLimiter
+// imposes no limits.
+type requestLimiter struct {
+	slots chan struct{}
+	rate  *rate.Limiter
+}
+
+// newRequestLimiter returns a limiter allowing maxConcurrent requests in
+// flight and perSecond new requests per second; zero disables either limit.
+func newRequestLimiter(maxConcurrent int64, perSecond float64) *requestLimiter {
+	if maxConcurrent <= 0 && perSecond <= 0 {
+		return nil
+	}
+	l := &requestLimiter{}
+	if maxConcurrent > 0 {
+		l.slots = make(chan struct{}, maxConcurrent)
+	}
+	if perSecond > 0 {
+		l.rate = rate.NewLimiter(rate.Limit(perSecond), 1)
+	}
+	return l
+}
+
+// acquire waits until a request may start and returns a function releasing
+// its slot, along with the time spent waiting.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), time.Duration, error) {
+	if l == nil {
+		return func() {}, 0, nil
+	}
+
+	start := time.Now()
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, time.Since(start), ctx.Err()
+		}
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			if l.slots != nil {
+				<-l.slots
+			}
+		})
+	}
+
+	if l.rate != nil {
+		if err := l.rate.Wait(ctx); err != nil {
+			release()
+			return nil, time.Since(start), err
+		}
+	}
+	return release, time.Since(start), nil
+}
+
+// releasingBody releases a request slot once the response body is closed, so
+// the slot is held until the response has been fully read.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
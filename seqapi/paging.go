@@ -0,0 +1,196 @@
+package seqapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// defaultPageSize is the page size ListPages requests when
+// PageOptions.PageSize is not set.
+const defaultPageSize = 100
+
+// PageOptions controls a paged listing such as /api/events.
+type PageOptions struct {
+	// PageSize is the number of items requested per page (Seq's count); 0
+	// requests pages of 100.
+	PageSize int
+	// Limit stops the listing after this many items; 0 lists everything.
+	Limit int
+	// ShortCircuitAfter, if set, is passed to Seq to bound how many items
+	// the server examines while filling each page.
+	ShortCircuitAfter int
+}
+
+// ListPages iterates over a collection that Seq pages with count and afterId,
+// requesting pages lazily as the caller consumes items. cursor returns the id
+// passed as afterId to fetch the page following an item. query is not
+// modified.
+//
+// Each page is decoded incrementally and its response closed before its items
+// are yielded, so callers may make further requests while iterating without
+// holding a request slot.
+func ListPages[T any](ctx context.Context, c *Client, path string, query url.Values, opts PageOptions, cursor func(T) string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		if opts.ShortCircuitAfter > 0 {
+			q.Set("shortCircuitAfter", strconv.Itoa(opts.ShortCircuitAfter))
+		}
+
+		var zero T
+		seen, after := 0, ""
+		for opts.Limit <= 0 || seen < opts.Limit {
+			count := opts.PageSize
+			if count <= 0 {
+				count = defaultPageSize
+			}
+			if remaining := opts.Limit - seen; opts.Limit > 0 && remaining < count {
+				count = remaining
+			}
+			q.Set("count", strconv.Itoa(count))
+			if after != "" {
+				q.Set("afterId", after)
+			}
+
+			var page []T
+			err := fetchList(ctx, c, path+"?"+q.Encode(), func(item T) bool {
+				page = append(page, item)
+				return true
+			})
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			seen += len(page)
+
+			if len(page) == 0 || len(page) < count {
+				return
+			}
+			next := cursor(page[len(page)-1])
+			if next == "" || next == after {
+				// The endpoint ignored afterId; stop rather than loop.
+				return
+			}
+			after = next
+		}
+	}
+}
+
+// ListItems iterates over a collection Seq returns in a single response, such
+// as /api/apikeys. Items are yielded as they are decoded from the response,
+// which stays open, holding a request slot, until the loop ends; with
+// Config.MaxConcurrentRequests set, collect the items first if the loop body
+// makes further requests.
+func ListItems[T any](ctx context.Context, c *Client, path string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		stopped := false
+		err := fetchList(ctx, c, path, func(item T) bool {
+			stopped = !yield(item, nil)
+			return !stopped
+		})
+		if err != nil && !stopped {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// fetchList GETs path and decodes the JSON array it returns one element at a
+// time, without buffering the response body, calling fn with each element
+// until it returns false. A null or empty body is an empty list.
+func fetchList[T any](ctx context.Context, c *Client, path string, fn func(T) bool) error {
+	resp, err := c.send(ctx, http.MethodGet, path, "", nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	logged := &cappedBuffer{limit: maxLoggedBodyBytes + 1}
+	body := io.TeeReader(resp.Body, logged)
+	defer func() {
+		logResponseBody(resp.Request.Context(), resp, logged.Bytes(), requestSecrets(c.apiKey))
+	}()
+
+	complete, err := decodeJSONArray(body, fn)
+	if err != nil {
+		return fmt.Errorf("decode JSON response: %w", err)
+	}
+	if complete {
+		_, _ = io.Copy(io.Discard, body)
+	}
+	return nil
+}
+
+// decodeJSONArray decodes a JSON array from r, calling fn with each element as
+// it is read. It stops early, reporting false, when fn returns false.
+func decodeJSONArray[T any](r io.Reader, fn func(T) bool) (bool, error) {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if errors.Is(err, io.EOF) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if tok == nil {
+		return true, nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return false, fmt.Errorf("expected a JSON array, got %v", tok)
+	}
+
+	for dec.More() {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return false, err
+		}
+		if !fn(item) {
+			return false, nil
+		}
+	}
+	_, err = dec.Token()
+	return err == nil, err
+}
+
+// cappedBuffer keeps the first limit bytes written to it and discards the
+// rest, so streamed responses can still be logged.
+type cappedBuffer struct {
+	limit int
+	buf   []byte
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - len(b.buf); room > 0 {
+		if len(p) < room {
+			room = len(p)
+		}
+		b.buf = append(b.buf, p[:room]...)
+	}
+	return len(p), nil
+}
+
+func (b *cappedBuffer) Bytes() []byte {
+	return b.buf
+}
+
+// failedList is a listing that yields err, e.g. for an unsupported resource.
+func failedList[T any](err error) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		yield(zero, err)
+	}
+}
@@ -0,0 +1,253 @@
+package seqapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type pagedItem struct {
+	ID string `json:"Id"`
+}
+
+func pagedItemID(item pagedItem) string {
+	return item.ID
+}
+
+// newPagedServer serves n items at /api/items, newest (highest) first, paged
+// with count and afterId like /api/events.
+func newPagedServer(t *testing.T, n int, queries *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.RawQuery)
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+		start := n
+		if after := r.URL.Query().Get("afterId"); after != "" {
+			start, _ = strconv.Atoi(strings.TrimPrefix(after, "item-"))
+			start--
+		}
+		var items []string
+		for i := start; i > 0 && len(items) < count; i-- {
+			items = append(items, fmt.Sprintf(`{"Id": "item-%d"}`, i))
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(items, ",") + "]"))
+	}))
+}
+
+func collectPages(t *testing.T, c *Client, opts PageOptions) []string {
+	t.Helper()
+	var ids []string
+	for item, err := range ListPages(context.Background(), c, "/api/items", nil, opts, pagedItemID) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func TestListPagesFollowsAfterID(t *testing.T) {
+	var queries []string
+	srv := newPagedServer(t, 5, &queries)
+	defer srv.Close()
+	c := &Client{baseURL: mustParseURL(srv.URL), http: srv.Client()}
+
+	ids := collectPages(t, c, PageOptions{PageSize: 2})
+	if strings.Join(ids, ",") != "item-5,item-4,item-3,item-2,item-1" {
+		t.Fatalf("unexpected items %v", ids)
+	}
+	want := []string{"count=2", "afterId=item-4&count=2", "afterId=item-2&count=2"}
+	if strings.Join(queries, " ") != strings.Join(want, " ") {
+		t.Fatalf("unexpected queries %v", queries)
+	}
+}
+
+func TestListPagesLimitAndShortCircuit(t *testing.T) {
+	var queries []string
+	srv := newPagedServer(t, 10, &queries)
+	defer srv.Close()
+	c := &Client{baseURL: mustParseURL(srv.URL), http: srv.Client()}
+
+	ids := collectPages(t, c, PageOptions{PageSize: 2, Limit: 3, ShortCircuitAfter: 50})
+	if strings.Join(ids, ",") != "item-10,item-9,item-8" {
+		t.Fatalf("unexpected items %v", ids)
+	}
+	want := []string{"count=2&shortCircuitAfter=50", "afterId=item-9&count=1&shortCircuitAfter=50"}
+	if strings.Join(queries, " ") != strings.Join(want, " ") {
+		t.Fatalf("unexpected queries %v", queries)
+	}
+}
+
+func TestListPagesStopsWhenCallerBreaks(t *testing.T) {
+	var queries []string
+	srv := newPagedServer(t, 10, &queries)
+	defer srv.Close()
+	c := &Client{baseURL: mustParseURL(srv.URL), http: srv.Client()}
+
+	for item, err := range ListPages(context.Background(), c, "/api/items", nil, PageOptions{PageSize: 2}, pagedItemID) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if item.ID == "item-9" {
+			break
+		}
+	}
+	if len(queries) != 1 {
+		t.Fatalf("expected one page request, got %v", queries)
+	}
+}
+
+func TestListPagesDefaultsPageSize(t *testing.T) {
+	var queries []string
+	srv := newPagedServer(t, 0, &queries)
+	defer srv.Close()
+	c := &Client{baseURL: mustParseURL(srv.URL), http: srv.Client()}
+
+	ids := collectPages(t, c, PageOptions{})
+	if len(ids) != 0 {
+		t.Fatalf("unexpected items %v", ids)
+	}
+	if strings.Join(queries, " ") != "count=100" {
+		t.Fatalf("unexpected queries %v", queries)
+	}
+}
+
+func TestListPagesStopsWhenAfterIDIsIgnored(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`[{"Id": "a"}, {"Id": "b"}]`))
+	}))
+	defer srv.Close()
+	c := &Client{baseURL: mustParseURL(srv.URL), http: srv.Client()}
+
+	ids := collectPages(t, c, PageOptions{PageSize: 2})
+	if len(ids) != 4 || requests != 2 {
+		t.Fatalf("expected paging to stop after a repeated page, got %v in %d requests", ids, requests)
+	}
+}
+
+func TestListItemsDecodesResponse(t *testing.T) {
+	cases := map[string]struct {
+		body    string
+		want    string
+		wantErr string
+	}{
+		"array":     {body: `[{"Id": "a"}, {"Id": "b"}]`, want: "a,b"},
+		"empty":     {body: ``, want: ""},
+		"null":      {body: `null`, want: ""},
+		"not array": {body: `{"Id": "a"}`, wantErr: "expected a JSON array"},
+		"truncated": {body: `[{"Id": "a"}, {"Id":`, wantErr: "decode JSON response"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+			c := &Client{baseURL: mustParseURL(srv.URL), http: srv.Client()}
+
+			var ids []string
+			var gotErr error
+			for item, err := range ListItems[pagedItem](context.Background(), c, "/api/items") {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				ids = append(ids, item.ID)
+			}
+			if tc.wantErr != "" {
+				if gotErr == nil || !strings.Contains(gotErr.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, gotErr)
+				}
+				return
+			}
+			if gotErr != nil {
+				t.Fatalf("unexpected error: %v", gotErr)
+			}
+			if strings.Join(ids, ",") != tc.want {
+				t.Fatalf("unexpected items %v", ids)
+			}
+		})
+	}
+}
+
+func TestListItemsYieldsItemsAsTheyArrive(t *testing.T) {
+	more := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"Id": "a"},`))
+		w.(http.Flusher).Flush()
+		select {
+		case <-more:
+		case <-r.Context().Done():
+			return
+		}
+		_, _ = w.Write([]byte(`{"Id": "b"}]`))
+	}))
+	defer srv.Close()
+	c := &Client{baseURL: mustParseURL(srv.URL), http: srv.Client()}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var ids []string
+	for item, err := range ListItems[pagedItem](ctx, c, "/api/items") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, item.ID)
+		if item.ID == "a" {
+			// The rest of the response is only sent once the first item
+			// has been yielded.
+			close(more)
+		}
+	}
+	if strings.Join(ids, ",") != "a,b" {
+		t.Fatalf("unexpected items %v", ids)
+	}
+}
+
+func TestListItemsStopsReadingWhenCallerBreaks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/items" {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"Id": "a"},`))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+	c := &Client{baseURL: mustParseURL(srv.URL), http: srv.Client(), limiter: newRequestLimiter(1, 0)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for item, err := range ListItems[pagedItem](ctx, c, "/api/items") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if item.ID == "a" {
+			break
+		}
+	}
+	// Breaking closes the response and frees the only request slot.
+	if err := c.DoJSON(ctx, http.MethodGet, "/health", nil, nil); err != nil {
+		t.Fatalf("request after break failed: %v", err)
+	}
+}
+
+func TestCappedBuffer(t *testing.T) {
+	b := &cappedBuffer{limit: 5}
+	for _, s := range []string{"abc", "defg", "hij"} {
+		if n, err := b.Write([]byte(s)); n != len(s) || err != nil {
+			t.Fatalf("Write(%q) = %d, %v", s, n, err)
+		}
+	}
+	if string(b.Bytes()) != "abcde" {
+		t.Fatalf("unexpected buffer %q", b.Bytes())
+	}
+}
@@ -0,0 +1,62 @@
+package seqapi
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// Permalink pins an event so it is retained and can be linked to.
+//
+// Ref: https://datalust.co/docs/server-http-api#api-permalinks
+type Permalink struct {
+	ID      string  `json:"Id"`
+	EventID string  `json:"EventId"`
+	OwnerID *string `json:"OwnerId"`
+}
+
+// ListPermalinks iterates over the permalinks visible to the client.
+func (c *Client) ListPermalinks(ctx context.Context) iter.Seq2[Permalink, error] {
+	path, err := c.ResourcePath(ResourcePermalinks)
+	if err != nil {
+		return failedList[Permalink](err)
+	}
+	return ListItems[Permalink](ctx, c, path)
+}
+
+// GetPermalink reads a permalink by id.
+func (c *Client) GetPermalink(ctx context.Context, id string) (Permalink, error) {
+	var permalink Permalink
+	path, err := c.ResourcePath(ResourcePermalinks)
+	if err != nil {
+		return permalink, err
+	}
+	err = c.GetEntity(ctx, path, id, &permalink)
+	return permalink, err
+}
+
+// CreatePermalink pins the event with the given id.
+func (c *Client) CreatePermalink(ctx context.Context, eventID string) (Permalink, error) {
+	var created Permalink
+	path, err := c.ResourcePath(ResourcePermalinks)
+	if err != nil {
+		return created, err
+	}
+	err = c.DoJSON(ctx, http.MethodPost, path, map[string]any{"EventId": eventID}, &created)
+	return created, err
+}
+
+// DeletePermalink deletes a permalink by id.
+func (c *Client) DeletePermalink(ctx context.Context, id string) error {
+	path, err := c.ResourcePath(ResourcePermalinks)
+	if err != nil {
+		return err
+	}
+	return c.DoJSON(ctx, http.MethodDelete, path+"/"+url.PathEscape(id), nil, nil)
+}
+
+// PermalinkURL returns the Seq UI link for a permalink.
+func (c *Client) PermalinkURL(id string) string {
+	return c.ServerURL() + "/#/events?permalink=" + url.QueryEscape(id)
+}
@@ -0,0 +1,44 @@
+package seqapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Names of Seq server settings.
+//
+// Ref: https://datalust.co/docs/server-http-api#api-settings
+const (
+	SettingBackupLocation     = "BackupLocation"
+	SettingBackupUtcTimeOfDay = "BackupUtcTimeOfDay"
+	SettingBackupsToKeep      = "BackupsToKeep"
+)
+
+// Setting is a server setting returned by /api/settings/{name}.
+type Setting struct {
+	ID    string `json:"Id"`
+	Name  string `json:"Name"`
+	Value any    `json:"Value"`
+}
+
+// GetSetting reads a server setting by name.
+func (c *Client) GetSetting(ctx context.Context, name string) (Setting, error) {
+	var got Setting
+	path, err := c.ResourcePath(ResourceSettings)
+	if err != nil {
+		return got, err
+	}
+	err = c.DoJSON(ctx, http.MethodGet, path+"/"+url.PathEscape(name), nil, &got)
+	return got, err
+}
+
+// PutSetting updates a server setting by name.
+func (c *Client) PutSetting(ctx context.Context, name string, value any) error {
+	path, err := c.ResourcePath(ResourceSettings)
+	if err != nil {
+		return err
+	}
+	body := Setting{ID: name, Name: name, Value: value}
+	return c.DoJSON(ctx, http.MethodPut, path+"/"+url.PathEscape(name), body, nil)
+}
@@ -0,0 +1,22 @@
+package seqapi
+
+import "strings"
+
+// tracerName is the instrumentation scope of the client's spans. Spans come
+// from the global OpenTelemetry tracer provider, so they are only recorded
+// when the application installs one.
+const tracerName = "github.com/alexdresko/terraform-provider-seq/seqapi"
+
+// spanRoute returns path without its query and with entity ids replaced by
+// {id}, e.g. /api/apikeys/{id}/metrics, to keep span names low-cardinality.
+// Path segments after the collection that contain a digit are taken as ids.
+func spanRoute(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if i > 2 && strings.ContainsAny(segment, "0123456789") {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,19 @@
+package seqapi
+
+import "testing"
+
+func TestSpanRoute(t *testing.T) {
+	cases := map[string]string{
+		"/api":                                  "/api",
+		"/api/apikeys?shortCircuitAfter=1":      "/api/apikeys",
+		"/api/apikeys/apikey-12/metrics":        "/api/apikeys/{id}/metrics",
+		"/api/expressions/to-strict?fuzzy=x":    "/api/expressions/to-strict",
+		"/api/settings/backupsToKeep":           "/api/settings/backupsToKeep",
+		"/api/backups/backup-20240101/download": "/api/backups/{id}/download",
+	}
+	for path, want := range cases {
+		if got := spanRoute(path); got != want {
+			t.Errorf("spanRoute(%q) = %q, want %q", path, got, want)
+		}
+	}
+}